@@ -2,28 +2,86 @@ package gcd
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"log/slog"
 	"net/http"
-
-	"golang.org/x/net/http2"
+	"net/url"
+	"runtime/debug"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 )
 
 const (
 	DefaultPrefix    = "https://www.comics.org/api"
-	DefaultUserAgent = "GCD Client/Go 2024.11.13"
+	DefaultUserAgent = "GCD Client/Go" // followed by the library version, see API.UserAgent
+
+	modulePath = "github.com/ipkgs/go-gcd"
 )
 
-var defaultHTTPClient = &http.Client{Transport: &http2.Transport{}}
+var defaultHTTPClient = newDefaultHTTPClient()
+
+func newDefaultHTTPClient() *http.Client {
+	transport, err := NewTransport(TransportOptions{})
+	if err != nil {
+		panic(err) // cannot happen with the default options
+	}
+
+	return &http.Client{Transport: transport}
+}
 
 type HTTPDoer interface {
 	Do(*http.Request) (*http.Response, error)
 }
 
+// API calls the gcd api. Its zero value is ready to use; New builds a validated Client around it.
 type API struct {
 	Prefix string
-	Client HTTPDoer // override the client. Note that the gcd api only accept requests with HTTP/2, so http.DefaultClient is not compatible
+	Client HTTPDoer // override the client. The gcd api only accepts HTTP/2 requests, see NewTransport to build a compatible one
+
+	SessionID   string              // optional cookie value for gcdsessionid
+	Credentials CredentialsProvider // optional, used to log in again when the session expires
+	Sessions    SessionStore        // optional, shares the session between copies and calls, renewed ones included; overrides SessionID once filled
+
+	UserAgent string      // identifies the integrator, e.g. "MyApp/1.2 (+https://example.com)"; the library version is appended
+	Headers   http.Header // optional extra headers sent with every request, e.g. From or Accept-Language
+
+	Timeout time.Duration // optional limit for a whole call, retries included
+
+	Retry *RetryPolicy // optional retry policy, nil disables retries
+
+	Limiter        *RateLimiter // optional limiter shared by all requests
+	SessionLimiter *RateLimiter // optional limiter used instead of Limiter when SessionID is set
+
+	Prefetch bool // fetch the next page concurrently while iterating over paginated results
+
+	Strict bool // report payloads with unknown or missing fields as *SchemaDriftError
+
+	MaxResponseSize int64 // maximum response body size in bytes, defaults to DefaultMaxResponseSize; negative disables the limit
+
+	Cache      Cache         // optional response cache
+	CacheTTL   time.Duration // freshness of cached responses when the server does not specify one
+	CacheStats *CacheStats   // optional counters of cache outcomes, shared by every copy of the API
+
+	Middlewares []Middleware // wrap Client, applied in order: the first one is the outermost
 
-	SessionID string // optional cookie value for gcdsessionid
+	Logger *slog.Logger // optional, logs one record per call; debug level adds payload sizes and schema drift
+
+	Tracer Tracer // optional, traces every call and each HTTP attempt made for it
+
+	Metrics Metrics // optional, e.g. NewPrometheusMetrics()
+}
+
+func (a API) prefix() string {
+	if a.Prefix == "" {
+		return DefaultPrefix
+	}
+
+	return a.Prefix
 }
 
 func (a API) client() HTTPDoer {
@@ -34,7 +92,163 @@ func (a API) client() HTTPDoer {
 	return defaultHTTPClient
 }
 
-func (a API) req(ctx context.Context, url string) (*http.Response, error) {
+// userAgent returns the User-Agent header value: the configured UserAgent followed by the library's own.
+func (a API) userAgent() string {
+	library := DefaultUserAgent + " " + libraryVersion()
+	if a.UserAgent == "" {
+		return library
+	}
+
+	return a.UserAgent + " " + library
+}
+
+// libraryVersion reports the version of this module in the running binary, or "devel" when it is unknown.
+var libraryVersion = sync.OnceValue(func() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "devel"
+	}
+
+	version := info.Main.Version
+	if info.Main.Path != modulePath {
+		version = ""
+
+		for _, dep := range info.Deps {
+			if dep.Path == modulePath {
+				version = dep.Version
+				if dep.Replace != nil && dep.Replace.Version != "" {
+					version = dep.Replace.Version
+				}
+
+				break
+			}
+		}
+	}
+
+	if version == "" || version == "(devel)" {
+		return "devel"
+	}
+
+	return version
+})
+
+func (a API) limiter() *RateLimiter {
+	if a.SessionID != "" && a.SessionLimiter != nil {
+		return a.SessionLimiter
+	}
+
+	return a.Limiter
+}
+
+func (a API) req(ctx context.Context, url string, call *callInfo) (*http.Response, error) {
+	if a.Cache != nil {
+		return a.cachedReq(ctx, url, call)
+	}
+
+	return a.fetch(ctx, url, nil, call)
+}
+
+// fetch performs the request, waiting for the limiter and retrying according to the retry policy.
+// header holds extra request headers, such as conditional request validators.
+func (a API) fetch(ctx context.Context, url string, header http.Header, call *callInfo) (*http.Response, error) {
+	attempts := a.Retry.attempts()
+	limiter := a.limiter()
+	reauthenticated := false
+
+	for attempt := 1; ; attempt++ {
+		if limiter != nil {
+			waited, err := limiter.wait(ctx)
+			call.limiterWait += waited
+
+			if err != nil {
+				return nil, fmt.Errorf("limiter.Wait: %w", err)
+			}
+		}
+
+		call.attempts++
+
+		attemptCtx, span := a.startAttemptSpan(ctx, url, call.attempts)
+		attemptStart := time.Now()
+
+		resp, err := a.do(attemptCtx, url, header)
+		endAttemptSpan(span, resp, err)
+		a.observeAttempt(call, resp, err, time.Since(attemptStart))
+
+		if err == nil {
+			call.status = resp.StatusCode
+
+			if limiter != nil {
+				limiter.observe(resp.StatusCode)
+			}
+		}
+
+		if err == nil && a.Credentials != nil && !reauthenticated && sessionExpired(resp) {
+			// log in again once, without spending a retry
+			reauthenticated = true
+			drain(resp)
+
+			session, err := a.refreshSession(ctx, a.SessionID)
+			if err != nil {
+				return nil, err
+			}
+
+			a.SessionID = session.ID
+			limiter = a.limiter()
+			attempt--
+
+			continue
+		}
+
+		if attempt >= attempts {
+			return resp, err
+		}
+
+		var retryAfter time.Duration
+
+		switch {
+		case err != nil:
+			if !a.Retry.retryableError(ctx, err) {
+				return nil, err
+			}
+		case a.Retry.retryableStatus(resp.StatusCode):
+			retryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+			if a.Retry.MaxDelay > 0 && retryAfter > a.Retry.MaxDelay {
+				// the server asks for a longer wait than the policy allows
+				return resp, nil
+			}
+		default:
+			return resp, nil
+		}
+
+		delay := max(a.Retry.backoff(attempt), retryAfter)
+		if sleepErr := sleepCtx(ctx, delay); sleepErr != nil {
+			// out of time: hand back what the last attempt produced
+			return resp, err
+		}
+
+		if resp != nil {
+			drain(resp)
+		}
+	}
+}
+
+func (a API) do(ctx context.Context, url string, header http.Header) (*http.Response, error) {
+	httpReq, err := a.newRequest(ctx, url, header)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := a.doer().Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("client.Do: %w", err)
+	}
+
+	return resp, nil
+}
+
+// newRequest builds the GET request for url with the session cookie, the default and configured headers,
+// and header on top.
+func (a API) newRequest(ctx context.Context, url string, header http.Header) (*http.Request, error) {
 	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("http.NewRequestWithContext: %w", err)
@@ -49,14 +263,153 @@ func (a API) req(ctx context.Context, url string) (*http.Response, error) {
 		})
 	}
 
-	httpReq.Header.Set("User-Agent", DefaultUserAgent)
+	httpReq.Header.Set("User-Agent", a.userAgent())
 	httpReq.Header.Set("Accept", "application/json")
 	httpReq.Header.Set("Accept-Charset", "utf-8")
 
-	resp, err := a.client().Do(httpReq)
+	for key, values := range a.Headers {
+		httpReq.Header[http.CanonicalHeaderKey(key)] = slices.Clone(values)
+	}
+
+	for key, values := range header {
+		httpReq.Header[key] = values
+	}
+
+	return httpReq, nil
+}
+
+// resourceURL builds the URL of a gcd resource, either a single record by ID or a listing optionally narrowed by name.
+func resourceURL(prefix, resource string, id int, name, format string, page int) (string, error) {
+	if id < 0 {
+		return "", errors.New("if ID is provided, it needs to be greater than zero")
+	}
+
+	if id > 0 && name != "" {
+		return "", errors.New("cannot specify both ID and Name")
+	}
+
+	segments := []string{resource}
+
+	if id > 0 {
+		segments = append(segments, strconv.Itoa(id))
+	}
+
+	if name != "" {
+		segments = append(segments, "name", name)
+	}
+
+	return buildURL(prefix, segments, requestQuery(format, page))
+}
+
+// requestQuery returns the optional format and page query parameters.
+func requestQuery(format string, page int) url.Values {
+	query := url.Values{}
+
+	if format != "" {
+		query.Set("format", format)
+	}
+
+	if page > 0 {
+		query.Set("page", strconv.Itoa(page))
+	}
+
+	return query
+}
+
+// buildURL appends the path segments to prefix, escaping each of them, so a name such as "Spider-Man/Deadpool"
+// stays a single segment. The URL always ends with a slash, as the gcd api expects.
+func buildURL(prefix string, segments []string, query url.Values) (string, error) {
+	if prefix == "" {
+		return "", errors.New("empty prefix")
+	}
+
+	u, err := url.Parse(prefix)
 	if err != nil {
-		return nil, fmt.Errorf("client.Do: %w", err)
+		return "", fmt.Errorf("url.Parse: %w", err)
 	}
 
-	return resp, nil
+	var b strings.Builder
+
+	b.WriteString(strings.TrimSuffix(u.EscapedPath(), "/"))
+
+	for _, segment := range segments {
+		b.WriteByte('/')
+
+		if strings.Trim(segment, ".") == "" && segment != "" {
+			// url.PathEscape keeps dots, and "." or ".." would be resolved as relative path segments
+			b.WriteString(strings.Repeat("%2E", len(segment)))
+
+			continue
+		}
+
+		b.WriteString(url.PathEscape(segment))
+	}
+
+	b.WriteByte('/')
+
+	u.RawPath = b.String()
+	if u.Path, err = url.PathUnescape(u.RawPath); err != nil {
+		return "", fmt.Errorf("url.PathUnescape: %w", err)
+	}
+
+	u.RawQuery = query.Encode()
+
+	return u.String(), nil
+}
+
+// getAs performs a GET request to url and decodes the JSON response into a T.
+func getAs[T any](ctx context.Context, a API, url string) (T, error) {
+	var v T
+
+	if err := a.get(ctx, url, &v); err != nil {
+		return v, err
+	}
+
+	return v, nil
+}
+
+// get performs a GET request to url and decodes the JSON response into v.
+// Non-2xx responses are returned as *APIError.
+func (a API) get(ctx context.Context, url string, v any) (err error) {
+	call := &callInfo{}
+	start := time.Now()
+
+	if a.Timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, a.Timeout)
+		defer cancel()
+	}
+
+	ctx, span := a.startCallSpan(ctx, url)
+
+	if a.Metrics != nil {
+		call.endpoint = endpointLabel(url)
+	}
+
+	defer func() {
+		a.observeCall(call)
+		endCallSpan(span, call, err)
+		a.logCall(ctx, url, call, time.Since(start), err)
+	}()
+
+	if a, err = a.resolveSession(ctx); err != nil {
+		return err
+	}
+
+	resp, err := a.req(ctx, url, call)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	resp.Body = readCloser{Reader: countingReader{r: resp.Body, n: &call.bytes}, Closer: resp.Body}
+
+	return a.decodeResponse(ctx, resp, v)
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
 }
@@ -1,8 +1,14 @@
 package gcd
 
 import (
+	"context"
+	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestMain(m *testing.M) {
@@ -10,3 +16,70 @@ func TestMain(m *testing.M) {
 
 	m.Run()
 }
+
+func TestAPI_userAgent(t *testing.T) {
+	t.Parallel()
+
+	library := DefaultUserAgent + " " + libraryVersion()
+	assert.NotEmpty(t, libraryVersion())
+
+	assert.Equal(t, library, API{}.userAgent())
+	assert.Equal(t, "MyApp/1.0 "+library, API{UserAgent: "MyApp/1.0"}.userAgent())
+}
+
+func TestAPI_Headers(t *testing.T) {
+	t.Parallel()
+
+	var got http.Header
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintln(w, superman2023_1Issue)
+	}))
+	t.Cleanup(server.Close)
+
+	api := API{
+		Prefix:    "http://" + server.Listener.Addr().String() + "/api/",
+		UserAgent: "MyApp/1.0 (+https://example.com)",
+		Headers: http.Header{
+			"from":            {"dev@example.com"},
+			"Accept-Language": {"pt-BR"},
+		},
+	}
+
+	_, err := api.Issue(context.Background(), IssueReq{ID: 2495111})
+	require.NoError(t, err)
+
+	assert.Equal(t, "MyApp/1.0 (+https://example.com) "+DefaultUserAgent+" "+libraryVersion(), got.Get("User-Agent"))
+	assert.Equal(t, "dev@example.com", got.Get("From"))
+	assert.Equal(t, "pt-BR", got.Get("Accept-Language"))
+	assert.Equal(t, "application/json", got.Get("Accept"))
+}
+
+func TestBuildURL(t *testing.T) {
+	t.Parallel()
+
+	uu, err := resourceURL(TestPrefix+"/", "publisher", 0, "Marvel/Epic", "json", 3)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.org/api/publisher/name/Marvel%2FEpic/?format=json&page=3", uu)
+
+	uu, err = buildURL("https://example.org/gcd%20mirror/api", []string{"issue", "1"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.org/gcd%20mirror/api/issue/1/", uu)
+
+	uu, err = buildURL(TestPrefix, []string{"series", "name", "..", "year", "."}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.org/api/series/name/%2E%2E/year/%2E/", uu)
+
+	uu, err = IssueReq{ID: 1, Format: "a&b"}.URL(TestPrefix)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.org/api/issue/1/?format=a%26b", uu)
+
+	_, err = IssueReq{ID: 1}.URL("")
+	require.Error(t, err)
+
+	_, err = SeriesReq{ID: 1}.URL("https://example.org:port/api")
+	require.Error(t, err)
+}
@@ -0,0 +1,310 @@
+package gcd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	sessionCookie = "gcdsessionid"
+	csrfCookie    = "csrftoken"
+	loginPath     = "/accounts/login/"
+
+	// maxLoginPageSize bounds how much of the login page is read looking for the CSRF token.
+	maxLoginPageSize = 1 << 20
+)
+
+var (
+	ErrLoginFailed   = errors.New("gcd: login failed")
+	ErrNoCredentials = errors.New("gcd: no credentials")
+)
+
+// CredentialsProvider supplies the username and password used to log in.
+type CredentialsProvider interface {
+	Credentials(ctx context.Context) (username, password string, err error)
+}
+
+// CredentialsFunc adapts a function to CredentialsProvider.
+type CredentialsFunc func(ctx context.Context) (username, password string, err error)
+
+func (f CredentialsFunc) Credentials(ctx context.Context) (string, string, error) {
+	return f(ctx)
+}
+
+// StaticCredentials is a fixed username and password.
+type StaticCredentials struct {
+	Username string
+	Password string
+}
+
+func (c StaticCredentials) Credentials(context.Context) (string, string, error) {
+	if c.Username == "" || c.Password == "" {
+		return "", "", ErrNoCredentials
+	}
+
+	return c.Username, c.Password, nil
+}
+
+// EnvCredentials reads the username and password from environment variables, e.g. GCD_USERNAME and GCD_PASSWORD.
+func EnvCredentials(usernameVar, passwordVar string) CredentialsProvider {
+	return CredentialsFunc(func(context.Context) (string, string, error) {
+		username, password := os.Getenv(usernameVar), os.Getenv(passwordVar)
+		if username == "" || password == "" {
+			return "", "", fmt.Errorf("%w: %s and %s must be set", ErrNoCredentials, usernameVar, passwordVar)
+		}
+
+		return username, password, nil
+	})
+}
+
+// FileCredentials reads the username from the first line of the file at path and the password from the second.
+// The file is read on every login, so it can be rotated without restarting.
+func FileCredentials(path string) CredentialsProvider {
+	return CredentialsFunc(func(context.Context) (string, string, error) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", "", fmt.Errorf("os.ReadFile: %w", err)
+		}
+
+		lines := strings.SplitN(string(data), "\n", 3)
+		if len(lines) < 2 {
+			return "", "", fmt.Errorf("%w: %s needs the username and password on separate lines", ErrNoCredentials, path)
+		}
+
+		username, password := strings.TrimSpace(lines[0]), strings.TrimRight(lines[1], "\r")
+		if username == "" || password == "" {
+			return "", "", fmt.Errorf("%w: %s needs the username and password on separate lines", ErrNoCredentials, path)
+		}
+
+		return username, password, nil
+	})
+}
+
+// Login performs the comics.org form login and stores the session cookie in SessionID, and in Sessions when set.
+func (a *API) Login(ctx context.Context, username, password string) error {
+	session, err := a.login(ctx, username, password)
+	if err != nil {
+		return err
+	}
+
+	if err := a.saveSession(session); err != nil {
+		return err
+	}
+
+	a.SessionID = session.ID
+
+	return nil
+}
+
+// Authenticate logs in with the username and password supplied by Credentials, like Login.
+func (a *API) Authenticate(ctx context.Context) error {
+	session, err := a.authenticate(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := a.saveSession(session); err != nil {
+		return err
+	}
+
+	a.SessionID = session.ID
+
+	return nil
+}
+
+func (a API) authenticate(ctx context.Context) (Session, error) {
+	if a.Credentials == nil {
+		return Session{}, ErrNoCredentials
+	}
+
+	username, password, err := a.Credentials.Credentials(ctx)
+	if err != nil {
+		return Session{}, fmt.Errorf("CredentialsProvider.Credentials: %w", err)
+	}
+
+	return a.login(ctx, username, password)
+}
+
+// loginURL returns the login page of the site serving the api, e.g. https://www.comics.org/accounts/login/.
+func (a API) loginURL() (*url.URL, error) {
+	u, err := url.Parse(a.prefix())
+	if err != nil {
+		return nil, fmt.Errorf("url.Parse: %w", err)
+	}
+
+	path := strings.TrimSuffix(strings.TrimSuffix(u.Path, "/"), "/api")
+
+	return &url.URL{Scheme: u.Scheme, Host: u.Host, Path: path + loginPath}, nil
+}
+
+// login fetches the login form for its CSRF token, posts the credentials and captures the session cookie.
+func (a API) login(ctx context.Context, username, password string) (Session, error) {
+	loginURL, err := a.loginURL()
+	if err != nil {
+		return Session{}, err
+	}
+
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return Session{}, fmt.Errorf("cookiejar.New: %w", err)
+	}
+
+	doer := a.loginDoer()
+
+	resp, err := a.loginRequest(ctx, doer, jar, http.MethodGet, loginURL, nil)
+	if err != nil {
+		return Session{}, err
+	}
+
+	page, err := io.ReadAll(io.LimitReader(resp.Body, maxLoginPageSize))
+	resp.Body.Close()
+
+	if err != nil {
+		return Session{}, fmt.Errorf("io.ReadAll: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return Session{}, newAPIError(resp)
+	}
+
+	token := csrfToken(page, jar.Cookies(loginURL))
+	if token == "" {
+		return Session{}, fmt.Errorf("%w: no CSRF token on %s", ErrLoginFailed, loginURL)
+	}
+
+	form := url.Values{
+		"csrfmiddlewaretoken": {token},
+		"username":            {username},
+		"password":            {password},
+		"next":                {"/"},
+	}
+
+	resp, err = a.loginRequest(ctx, doer, jar, http.MethodPost, loginURL, form)
+	if err != nil {
+		return Session{}, err
+	}
+
+	drain(resp)
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return Session{}, newAPIError(resp)
+	}
+
+	for _, cookie := range resp.Cookies() {
+		if cookie.Name != sessionCookie || cookie.Value == "" {
+			continue
+		}
+
+		session := Session{ID: cookie.Value, Expires: cookie.Expires}
+		if cookie.MaxAge > 0 {
+			session.Expires = time.Now().Add(time.Duration(cookie.MaxAge) * time.Second)
+		}
+
+		return session, nil
+	}
+
+	// the form is rendered again, without a session, when the credentials are wrong
+	return Session{}, fmt.Errorf("%w: no session for %q", ErrLoginFailed, username)
+}
+
+func (a API) loginRequest(ctx context.Context, doer HTTPDoer, jar http.CookieJar, method string, u *url.URL, form url.Values) (*http.Response, error) {
+	var body io.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("http.NewRequestWithContext: %w", err)
+	}
+
+	httpReq.Header.Set("User-Agent", a.userAgent())
+
+	for key, values := range a.Headers {
+		httpReq.Header[http.CanonicalHeaderKey(key)] = slices.Clone(values)
+	}
+
+	if form != nil {
+		httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		httpReq.Header.Set("Referer", u.String()) // django checks it on https
+	}
+
+	for _, cookie := range jar.Cookies(u) {
+		httpReq.AddCookie(cookie)
+	}
+
+	resp, err := doer.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("client.Do: %w", err)
+	}
+
+	jar.SetCookies(u, resp.Cookies())
+
+	return resp, nil
+}
+
+// loginDoer returns the middleware chain around a client that does not follow redirects, so the session
+// cookie set on the redirect after a successful login is not lost.
+func (a API) loginDoer() HTTPDoer {
+	client := a.client()
+
+	if c, ok := client.(*http.Client); ok {
+		noRedirect := *c
+		noRedirect.Jar = nil // cookies are handled by login
+		noRedirect.CheckRedirect = func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
+		client = &noRedirect
+	}
+
+	return a.chain(client)
+}
+
+var (
+	csrfInputRe = regexp.MustCompile(`<input[^>]*name=["']csrfmiddlewaretoken["'][^>]*>`)
+	valueAttrRe = regexp.MustCompile(`value=["']([^"']+)["']`)
+)
+
+// csrfToken finds the token of the login form, falling back to the csrftoken cookie.
+func csrfToken(page []byte, cookies []*http.Cookie) string {
+	if input := csrfInputRe.Find(page); input != nil {
+		if m := valueAttrRe.FindSubmatch(input); m != nil {
+			return string(m[1])
+		}
+	}
+
+	for _, cookie := range cookies {
+		if cookie.Name == csrfCookie {
+			return cookie.Value
+		}
+	}
+
+	return ""
+}
+
+// sessionExpired reports whether resp shows the request was not authenticated: a 401 or 403, or a redirect to
+// the login page, whether it was followed or not.
+func sessionExpired(resp *http.Response) bool {
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return true
+	case resp.StatusCode >= 300 && resp.StatusCode < 400:
+		location, err := resp.Location()
+
+		return err == nil && strings.HasSuffix(location.Path, loginPath)
+	case resp.Request != nil && resp.Request.URL != nil:
+		return strings.HasSuffix(resp.Request.URL.Path, loginPath)
+	}
+
+	return false
+}
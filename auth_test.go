@@ -0,0 +1,256 @@
+package gcd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeGCD stands in for comics.org: a django style login form and an api that requires a session.
+type fakeGCD struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	session string // the only valid session
+
+	logins     atomic.Int32
+	redirectOK bool // answer unauthenticated api requests with a redirect to the login page instead of 403
+}
+
+func newFakeGCD(t *testing.T) *fakeGCD {
+	t.Helper()
+
+	gcd := &fakeGCD{redirectOK: true}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /accounts/login/", func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: csrfCookie, Value: "csrf-secret", Path: "/"})
+		fmt.Fprintln(w, `<form method="post"><input type="hidden" name="csrfmiddlewaretoken" value="form-token">`)
+	})
+	mux.HandleFunc("POST /accounts/login/", func(w http.ResponseWriter, r *http.Request) {
+		cookie, err := r.Cookie(csrfCookie)
+		if err != nil || cookie.Value != "csrf-secret" || r.PostFormValue("csrfmiddlewaretoken") != "form-token" {
+			w.WriteHeader(http.StatusForbidden)
+
+			return
+		}
+
+		if r.PostFormValue("username") != "jane" || r.PostFormValue("password") != "secret" {
+			fmt.Fprintln(w, `<p>Please enter a correct username and password.</p>`)
+
+			return
+		}
+
+		n := gcd.logins.Add(1)
+
+		gcd.mu.Lock()
+		gcd.session = fmt.Sprintf("session-%d", n)
+		gcd.mu.Unlock()
+
+		http.SetCookie(w, &http.Cookie{Name: sessionCookie, Value: gcd.session, Path: "/", MaxAge: 3600})
+		http.Redirect(w, r, r.PostFormValue("next"), http.StatusFound)
+	})
+	mux.HandleFunc("GET /", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "home")
+	})
+	mux.HandleFunc("GET /api/", func(w http.ResponseWriter, r *http.Request) {
+		gcd.mu.Lock()
+		valid := gcd.session
+		gcd.mu.Unlock()
+
+		if cookie, err := r.Cookie(sessionCookie); err != nil || cookie.Value != valid {
+			if gcd.redirectOK {
+				http.Redirect(w, r, loginPath+"?next="+r.URL.Path, http.StatusFound)
+			} else {
+				w.WriteHeader(http.StatusForbidden)
+			}
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintln(w, superman2023_1Issue)
+	})
+
+	gcd.Server = httptest.NewServer(mux)
+	t.Cleanup(gcd.Close)
+
+	return gcd
+}
+
+func (g *fakeGCD) prefix() string {
+	return "http://" + g.Listener.Addr().String() + "/api/"
+}
+
+func TestAPI_Login(t *testing.T) {
+	t.Parallel()
+
+	gcd := newFakeGCD(t)
+
+	api := API{Prefix: gcd.prefix()}
+
+	err := api.Login(context.Background(), "jane", "wrong")
+	require.ErrorIs(t, err, ErrLoginFailed)
+	assert.Empty(t, api.SessionID)
+
+	require.NoError(t, api.Login(context.Background(), "jane", "secret"))
+	assert.Equal(t, "session-1", api.SessionID)
+
+	_, err = api.Issue(context.Background(), IssueReq{ID: 2495111})
+	require.NoError(t, err)
+}
+
+func TestAPI_login_expiry(t *testing.T) {
+	t.Parallel()
+
+	gcd := newFakeGCD(t)
+
+	session, err := API{Prefix: gcd.prefix()}.login(context.Background(), "jane", "secret")
+	require.NoError(t, err)
+	assert.Equal(t, "session-1", session.ID)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), session.Expires, time.Minute)
+}
+
+func TestAPI_reauthenticate(t *testing.T) {
+	t.Parallel()
+
+	for _, redirect := range []bool{true, false} {
+		t.Run(fmt.Sprintf("redirect=%v", redirect), func(t *testing.T) {
+			t.Parallel()
+
+			gcd := newFakeGCD(t)
+			gcd.redirectOK = redirect
+
+			api := API{
+				Prefix:      gcd.prefix(),
+				SessionID:   "expired",
+				Credentials: StaticCredentials{Username: "jane", Password: "secret"},
+				Sessions:    NewMemorySessionStore(),
+			}
+
+			for range 2 {
+				issue, err := api.Issue(context.Background(), IssueReq{ID: 2495111})
+				require.NoError(t, err)
+				assert.Equal(t, "Superman (2023 series)", issue.SeriesName)
+			}
+
+			assert.EqualValues(t, 1, gcd.logins.Load(), "the renewed session reaches the next call")
+
+			session, err := api.CurrentSession()
+			require.NoError(t, err)
+			assert.Equal(t, "session-1", session.ID)
+		})
+	}
+}
+
+func TestAPI_reauthenticate_once(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case loginPath:
+			if r.Method == http.MethodPost {
+				http.SetCookie(w, &http.Cookie{Name: sessionCookie, Value: "fresh", Path: "/"})
+				http.Redirect(w, r, "/", http.StatusFound)
+
+				return
+			}
+
+			fmt.Fprintln(w, `<input name="csrfmiddlewaretoken" value="token" type="hidden">`)
+		default:
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	var requests atomic.Int32
+
+	api := API{
+		Prefix:      "http://" + server.Listener.Addr().String() + "/api/",
+		Credentials: StaticCredentials{Username: "jane", Password: "secret"},
+		Middlewares: []Middleware{func(next HTTPDoer) HTTPDoer {
+			return HTTPDoerFunc(func(r *http.Request) (*http.Response, error) {
+				requests.Add(1)
+
+				return next.Do(r)
+			})
+		}},
+	}
+
+	_, err := api.Issue(context.Background(), IssueReq{ID: 1})
+	require.ErrorIs(t, err, ErrUnauthorized)
+	assert.EqualValues(t, 4, requests.Load(), "api, login form, login post, api")
+}
+
+func TestAPI_Authenticate(t *testing.T) {
+	t.Parallel()
+
+	gcd := newFakeGCD(t)
+
+	api := API{Prefix: gcd.prefix()}
+	require.ErrorIs(t, api.Authenticate(context.Background()), ErrNoCredentials)
+
+	api.Credentials = CredentialsFunc(func(context.Context) (string, string, error) {
+		return "jane", "secret", nil
+	})
+	require.NoError(t, api.Authenticate(context.Background()))
+	assert.Equal(t, "session-1", api.SessionID)
+}
+
+func TestEnvCredentials(t *testing.T) {
+	t.Setenv("GCD_TEST_USERNAME", "jane")
+	t.Setenv("GCD_TEST_PASSWORD", "")
+
+	provider := EnvCredentials("GCD_TEST_USERNAME", "GCD_TEST_PASSWORD")
+
+	_, _, err := provider.Credentials(context.Background())
+	require.ErrorIs(t, err, ErrNoCredentials)
+
+	t.Setenv("GCD_TEST_PASSWORD", "secret")
+
+	username, password, err := provider.Credentials(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "jane", username)
+	assert.Equal(t, "secret", password)
+}
+
+func TestFileCredentials(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "credentials")
+	provider := FileCredentials(path)
+
+	_, _, err := provider.Credentials(context.Background())
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	require.NoError(t, os.WriteFile(path, []byte("jane\n"), 0o600))
+
+	_, _, err = provider.Credentials(context.Background())
+	require.ErrorIs(t, err, ErrNoCredentials)
+
+	require.NoError(t, os.WriteFile(path, []byte("jane\r\n s3cret \r\n"), 0o600))
+
+	username, password, err := provider.Credentials(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "jane", username)
+	assert.Equal(t, " s3cret ", password)
+}
+
+func TestCSRFToken(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "abc", csrfToken([]byte(`<input type='hidden' name='csrfmiddlewaretoken' value='abc'>`), nil))
+	assert.Equal(t, "abc", csrfToken([]byte(`<input value="abc" name="csrfmiddlewaretoken">`), nil))
+	assert.Equal(t, "cookie", csrfToken([]byte(`<form>`), []*http.Cookie{{Name: csrfCookie, Value: "cookie"}}))
+	assert.Empty(t, csrfToken([]byte(`<form>`), nil))
+}
@@ -0,0 +1,175 @@
+package gcd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+)
+
+// Brand is a brand emblem as printed on the cover, e.g. "DC [circle and serifs]".
+// The gcd api calls brand emblems simply "brands"; every emblem belongs to one or more brand groups.
+type Brand struct {
+	APIURL             string             `json:"api_url"`
+	Name               string             `json:"name"`
+	YearBegan          int                `json:"year_began"`
+	YearEnded          Optional[int]      `json:"year_ended"`
+	YearBeganUncertain bool               `json:"year_began_uncertain"`
+	YearEndedUncertain bool               `json:"year_ended_uncertain"`
+	URL                string             `json:"url"`
+	Notes              string             `json:"notes"`
+	Group              []Link[BrandGroup] `json:"group"`
+	IssueCount         int                `json:"issue_count"`
+
+	payload
+}
+
+type BrandReq struct {
+	ID   int // brand ID should not be provided together with the brand Name
+	Name string
+
+	Format string // optional: "api" or "json"
+	Page   int
+}
+
+func (r BrandReq) URL(prefix string) (string, error) {
+	return resourceURL(prefix, "brand", r.ID, r.Name, r.Format, r.Page)
+}
+
+type BrandResp struct {
+	Count    int     `json:"count"`
+	Next     string  `json:"next"`
+	Previous string  `json:"previous,omitempty"`
+	Results  []Brand `json:"results"`
+
+	payload
+}
+
+func (a API) BrandsFromURL(ctx context.Context, url string) (BrandResp, error) {
+	return getAs[BrandResp](ctx, a, url)
+}
+
+func (a API) Brands(ctx context.Context, req BrandReq) (BrandResp, error) {
+	uu, err := req.URL(a.prefix())
+	if err != nil {
+		return BrandResp{}, fmt.Errorf("failed to construct URL: %w", err)
+	}
+
+	return a.BrandsFromURL(ctx, uu)
+}
+
+// BrandsAll returns an iterator over every brand emblem matching req, following the next page links.
+func (a API) BrandsAll(ctx context.Context, req BrandReq) iter.Seq2[Brand, error] {
+	uu, err := req.URL(a.prefix())
+	if err != nil {
+		return func(yield func(Brand, error) bool) {
+			yield(Brand{}, fmt.Errorf("failed to construct URL: %w", err))
+		}
+	}
+
+	return Paginate(ctx, uu, func(ctx context.Context, url string) ([]Brand, string, error) {
+		resp, err := a.BrandsFromURL(ctx, url)
+
+		return resp.Results, resp.Next, err
+	}, a.Prefetch)
+}
+
+func (a API) BrandFromURL(ctx context.Context, url string) (Brand, error) {
+	return getAs[Brand](ctx, a, url)
+}
+
+func (a API) Brand(ctx context.Context, id int) (Brand, error) {
+	if id <= 0 {
+		return Brand{}, errors.New("invalid ID")
+	}
+
+	uu, err := BrandReq{ID: id}.URL(a.prefix())
+	if err != nil {
+		return Brand{}, fmt.Errorf("failed to construct URL: %w", err)
+	}
+
+	return a.BrandFromURL(ctx, uu)
+}
+
+// BrandGroup groups the brand emblems a publisher used for one imprint, e.g. "DC".
+type BrandGroup struct {
+	APIURL             string          `json:"api_url"`
+	Name               string          `json:"name"`
+	YearBegan          int             `json:"year_began"`
+	YearEnded          Optional[int]   `json:"year_ended"`
+	YearBeganUncertain bool            `json:"year_began_uncertain"`
+	YearEndedUncertain bool            `json:"year_ended_uncertain"`
+	URL                string          `json:"url"`
+	Notes              string          `json:"notes"`
+	Parent             Link[Publisher] `json:"parent"`
+	IssueCount         int             `json:"issue_count"`
+
+	payload
+}
+
+type BrandGroupReq struct {
+	ID   int // brand group ID should not be provided together with the brand group Name
+	Name string
+
+	Format string // optional: "api" or "json"
+	Page   int
+}
+
+func (r BrandGroupReq) URL(prefix string) (string, error) {
+	return resourceURL(prefix, "brand_group", r.ID, r.Name, r.Format, r.Page)
+}
+
+type BrandGroupResp struct {
+	Count    int          `json:"count"`
+	Next     string       `json:"next"`
+	Previous string       `json:"previous,omitempty"`
+	Results  []BrandGroup `json:"results"`
+
+	payload
+}
+
+func (a API) BrandGroupsFromURL(ctx context.Context, url string) (BrandGroupResp, error) {
+	return getAs[BrandGroupResp](ctx, a, url)
+}
+
+func (a API) BrandGroups(ctx context.Context, req BrandGroupReq) (BrandGroupResp, error) {
+	uu, err := req.URL(a.prefix())
+	if err != nil {
+		return BrandGroupResp{}, fmt.Errorf("failed to construct URL: %w", err)
+	}
+
+	return a.BrandGroupsFromURL(ctx, uu)
+}
+
+// BrandGroupsAll returns an iterator over every brand group matching req, following the next page links.
+func (a API) BrandGroupsAll(ctx context.Context, req BrandGroupReq) iter.Seq2[BrandGroup, error] {
+	uu, err := req.URL(a.prefix())
+	if err != nil {
+		return func(yield func(BrandGroup, error) bool) {
+			yield(BrandGroup{}, fmt.Errorf("failed to construct URL: %w", err))
+		}
+	}
+
+	return Paginate(ctx, uu, func(ctx context.Context, url string) ([]BrandGroup, string, error) {
+		resp, err := a.BrandGroupsFromURL(ctx, url)
+
+		return resp.Results, resp.Next, err
+	}, a.Prefetch)
+}
+
+func (a API) BrandGroupFromURL(ctx context.Context, url string) (BrandGroup, error) {
+	return getAs[BrandGroup](ctx, a, url)
+}
+
+func (a API) BrandGroup(ctx context.Context, id int) (BrandGroup, error) {
+	if id <= 0 {
+		return BrandGroup{}, errors.New("invalid ID")
+	}
+
+	uu, err := BrandGroupReq{ID: id}.URL(a.prefix())
+	if err != nil {
+		return BrandGroup{}, fmt.Errorf("failed to construct URL: %w", err)
+	}
+
+	return a.BrandGroupFromURL(ctx, uu)
+}
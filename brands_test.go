@@ -0,0 +1,178 @@
+package gcd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const dcCircleBrand = `{
+	"api_url": "https://www.comics.org/api/brand/4209/",
+	"name": "DC [circle and serifs]",
+	"year_began": 2016,
+	"year_ended": null,
+	"year_began_uncertain": false,
+	"year_ended_uncertain": false,
+	"url": "",
+	"notes": "",
+	"group": ["https://www.comics.org/api/brand_group/3474/"],
+	"issue_count": 6000
+}`
+
+const dcBrandGroup = `{
+	"api_url": "https://www.comics.org/api/brand_group/3474/",
+	"name": "DC",
+	"year_began": 1940,
+	"year_ended": null,
+	"url": "",
+	"notes": "",
+	"parent": "https://www.comics.org/api/publisher/54/",
+	"issue_count": 40000
+}`
+
+const dcIndiciaPublisher = `{
+	"api_url": "https://www.comics.org/api/indicia_publisher/2960/",
+	"name": "DC Comics",
+	"country": "us",
+	"year_began": 1977,
+	"year_ended": null,
+	"is_surrogate": false,
+	"url": "",
+	"notes": "",
+	"parent": "https://www.comics.org/api/publisher/54/",
+	"issue_count": 30000
+}`
+
+func TestBrandReqs_URL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		req      interface{ URL(string) (string, error) }
+		expected string
+	}{
+		{"brand-id", BrandReq{ID: 4209}, "https://example.org/api/brand/4209/"},
+		{"brand-name", BrandReq{Name: "DC", Page: 3}, "https://example.org/api/brand/name/DC/?page=3"},
+		{"brand-group-id", BrandGroupReq{ID: 3474}, "https://example.org/api/brand_group/3474/"},
+		{"indicia-publisher-id", IndiciaPublisherReq{ID: 2960}, "https://example.org/api/indicia_publisher/2960/"},
+		{"indicia-publisher-format", IndiciaPublisherReq{Format: "json"}, "https://example.org/api/indicia_publisher/?format=json"},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			resp, err := tt.req.URL(TestPrefix)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, resp)
+		})
+	}
+}
+
+func TestAPI_Brands(t *testing.T) {
+	t.Parallel()
+
+	responses := map[string]string{
+		"/api/brand/4209/":                dcCircleBrand,
+		"/api/brand/name/DC/":             `{"count": 1, "next": null, "results": [` + dcCircleBrand + `]}`,
+		"/api/brand_group/3474/":          dcBrandGroup,
+		"/api/brand_group/name/DC/":       `{"count": 1, "next": null, "results": [` + dcBrandGroup + `]}`,
+		"/api/indicia_publisher/2960/":    dcIndiciaPublisher,
+		"/api/indicia_publisher/name/DC/": `{"count": 1, "next": null, "results": [` + dcIndiciaPublisher + `]}`,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		respData, ok := responses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintln(w, respData)
+	}))
+	t.Cleanup(server.Close)
+
+	api := API{
+		Prefix: "http://" + server.Listener.Addr().String() + "/api/",
+	}
+
+	t.Run("brand", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := api.Brand(context.Background(), 4209)
+		require.NoError(t, err, "api.Brand")
+
+		assert.Equal(t, "DC [circle and serifs]", resp.Name)
+		assert.Equal(t, []Link[BrandGroup]{"https://www.comics.org/api/brand_group/3474/"}, resp.Group)
+	})
+
+	t.Run("brands", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := api.Brands(context.Background(), BrandReq{Name: "DC"})
+		require.NoError(t, err, "api.Brands")
+		require.Len(t, resp.Results, 1)
+		assert.Equal(t, 2016, resp.Results[0].YearBegan)
+	})
+
+	t.Run("brand-group", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := api.BrandGroup(context.Background(), 3474)
+		require.NoError(t, err, "api.BrandGroup")
+
+		assert.Equal(t, "DC", resp.Name)
+		assert.Equal(t, Link[Publisher]("https://www.comics.org/api/publisher/54/"), resp.Parent)
+	})
+
+	t.Run("indicia-publisher", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := api.IndiciaPublisher(context.Background(), 2960)
+		require.NoError(t, err, "api.IndiciaPublisher")
+
+		assert.Equal(t, "DC Comics", resp.Name)
+		assert.Equal(t, 1977, resp.YearBegan)
+	})
+
+	t.Run("all", func(t *testing.T) {
+		t.Parallel()
+
+		var names []string
+
+		for brand, err := range api.BrandsAll(context.Background(), BrandReq{Name: "DC"}) {
+			require.NoError(t, err)
+
+			names = append(names, brand.Name)
+		}
+
+		for group, err := range api.BrandGroupsAll(context.Background(), BrandGroupReq{Name: "DC"}) {
+			require.NoError(t, err)
+
+			names = append(names, group.Name)
+		}
+
+		for publisher, err := range api.IndiciaPublishersAll(context.Background(), IndiciaPublisherReq{Name: "DC"}) {
+			require.NoError(t, err)
+
+			names = append(names, publisher.Name)
+		}
+
+		assert.Equal(t, []string{"DC [circle and serifs]", "DC", "DC Comics"}, names)
+	})
+
+	t.Run("invalid-id", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := api.BrandGroup(context.Background(), 0)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,301 @@
+package gcd
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Cache stores api responses keyed by URL, session and configured headers. Implementations must be safe for concurrent use.
+type Cache interface {
+	Get(key string) (CacheEntry, bool)
+	Set(key string, entry CacheEntry, ttl time.Duration) // a ttl of zero or less keeps the entry until evicted
+}
+
+// CacheEntry is a cached successful response.
+type CacheEntry struct {
+	Header     http.Header
+	Body       []byte
+	FreshUntil time.Time   // after this the entry has to be revalidated with the server
+	Vary       http.Header // request values of the headers named by the Vary response header
+}
+
+// matches reports whether the entry was stored for a request with the same values in the headers it varies on.
+func (e CacheEntry) matches(header http.Header) bool {
+	for key, values := range e.Vary {
+		if !slices.Equal(values, header.Values(key)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (e CacheEntry) etag() string {
+	return e.Header.Get("ETag")
+}
+
+func (e CacheEntry) lastModified() string {
+	return e.Header.Get("Last-Modified")
+}
+
+// response builds an http.Response serving the cached body.
+func (e CacheEntry) response(ctx context.Context, url string) (*http.Response, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("http.NewRequestWithContext: %w", err)
+	}
+
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       httpReq,
+	}, nil
+}
+
+// CacheStats counts cache outcomes. The zero value is ready to use.
+type CacheStats struct {
+	Hits          atomic.Int64 // served from the cache without contacting the server
+	Misses        atomic.Int64 // fetched from the server
+	Revalidations atomic.Int64 // stale entries confirmed by the server with 304 Not Modified
+}
+
+func (s *CacheStats) hit() {
+	if s != nil {
+		s.Hits.Add(1)
+	}
+}
+
+func (s *CacheStats) miss() {
+	if s != nil {
+		s.Misses.Add(1)
+	}
+}
+
+func (s *CacheStats) revalidated() {
+	if s != nil {
+		s.Revalidations.Add(1)
+	}
+}
+
+// cachePolicy interprets the caching headers of a response. It returns how long the response is fresh and
+// whether it may be stored at all.
+func cachePolicy(header http.Header, now time.Time, defaultTTL time.Duration) (time.Duration, bool) {
+	var (
+		maxAge    = -1
+		noCache   bool
+		noStore   bool
+		hasMaxAge bool
+	)
+
+	// the response depends on something other than the request headers, so it can never be reused
+	for _, name := range varyHeaders(header) {
+		if name == "*" {
+			return 0, false
+		}
+	}
+
+	for _, directive := range strings.Split(header.Get("Cache-Control"), ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(directive), "=")
+
+		switch strings.ToLower(name) {
+		case "no-store":
+			noStore = true
+		case "no-cache":
+			noCache = true
+		case "max-age":
+			if secs, err := strconv.Atoi(strings.Trim(value, `"`)); err == nil {
+				maxAge = max(secs, 0)
+				hasMaxAge = true
+			}
+		}
+	}
+
+	if noStore {
+		return 0, false
+	}
+
+	hasValidators := header.Get("ETag") != "" || header.Get("Last-Modified") != ""
+
+	var freshness time.Duration
+
+	switch {
+	case noCache:
+	case hasMaxAge:
+		freshness = time.Duration(maxAge) * time.Second
+	case header.Get("Expires") != "":
+		if expires, err := http.ParseTime(header.Get("Expires")); err == nil && expires.After(now) {
+			freshness = expires.Sub(now)
+		}
+	default:
+		freshness = defaultTTL
+	}
+
+	return freshness, freshness > 0 || hasValidators
+}
+
+// cachedReq serves url from the cache when the entry is fresh, revalidates it when stale, and stores
+// cacheable responses.
+func (a API) cachedReq(ctx context.Context, url string, call *callInfo) (*http.Response, error) {
+	httpReq, err := a.newRequest(ctx, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	key := a.cacheKey(url)
+
+	entry, cached := a.Cache.Get(key)
+	cached = cached && entry.matches(httpReq.Header)
+
+	if cached && time.Now().Before(entry.FreshUntil) {
+		a.CacheStats.hit()
+		call.cache = cacheHit
+		call.status = http.StatusOK
+
+		return entry.response(ctx, url)
+	}
+
+	var header http.Header
+
+	if cached {
+		header = make(http.Header)
+
+		if etag := entry.etag(); etag != "" {
+			header.Set("If-None-Match", etag)
+		}
+
+		if lastModified := entry.lastModified(); lastModified != "" {
+			header.Set("If-Modified-Since", lastModified)
+		}
+	}
+
+	resp, err := a.fetch(ctx, url, header, call)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	if cached && resp.StatusCode == http.StatusNotModified {
+		drain(resp)
+
+		// a 304 carries the current caching headers, which replace the stored ones
+		entry.Header = entry.Header.Clone()
+		for _, key := range []string{"Cache-Control", "Expires", "ETag", "Last-Modified", "Date"} {
+			if value := resp.Header.Get(key); value != "" {
+				entry.Header.Set(key, value)
+			}
+		}
+
+		freshness, _ := cachePolicy(entry.Header, now, a.CacheTTL)
+		entry.FreshUntil = now.Add(freshness)
+		a.Cache.Set(key, entry, cacheStorageTTL(entry, freshness))
+		a.CacheStats.revalidated()
+		call.cache = cacheRevalidated
+
+		return entry.response(ctx, url)
+	}
+
+	a.CacheStats.miss()
+	call.cache = cacheMiss
+
+	if resp.StatusCode != http.StatusOK {
+		return resp, nil
+	}
+
+	freshness, storable := cachePolicy(resp.Header, now, a.CacheTTL)
+	if !storable {
+		return resp, nil
+	}
+
+	defer resp.Body.Close()
+
+	body := io.Reader(resp.Body)
+	if limit := a.maxResponseSize(); limit > 0 {
+		body = &limitedReader{r: resp.Body, n: limit, err: &ResponseTooLargeError{URL: url, Limit: limit}}
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("io.ReadAll: %w", err)
+	}
+
+	entry = CacheEntry{
+		Header:     resp.Header.Clone(),
+		Body:       data,
+		FreshUntil: now.Add(freshness),
+	}
+
+	// cookies belong to the session, not to the resource, and FileCache would write them to disk
+	entry.Header.Del("Set-Cookie")
+	entry.Header.Del("Set-Cookie2")
+
+	for _, name := range varyHeaders(resp.Header) {
+		if entry.Vary == nil {
+			entry.Vary = make(http.Header)
+		}
+
+		entry.Vary[name] = slices.Clone(httpReq.Header.Values(name))
+	}
+
+	a.Cache.Set(key, entry, cacheStorageTTL(entry, freshness))
+
+	return entry.response(ctx, url)
+}
+
+// cacheKey is url, qualified by the session and configured headers when set, so responses served to one
+// session or language are not handed to another.
+func (a API) cacheKey(url string) string {
+	if a.SessionID == "" && len(a.Headers) == 0 {
+		return url
+	}
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%q", a.SessionID)
+
+	for _, key := range slices.Sorted(maps.Keys(a.Headers)) {
+		fmt.Fprintf(h, "\n%s: %q", http.CanonicalHeaderKey(key), a.Headers[key])
+	}
+
+	return url + " " + hex.EncodeToString(h.Sum(nil))
+}
+
+// varyHeaders returns the canonical header names listed by the Vary headers of a response.
+func varyHeaders(header http.Header) []string {
+	var names []string
+
+	for _, value := range header.Values("Vary") {
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, http.CanonicalHeaderKey(name))
+			}
+		}
+	}
+
+	return names
+}
+
+// cacheStorageTTL keeps entries that can be revalidated around after they go stale.
+func cacheStorageTTL(entry CacheEntry, freshness time.Duration) time.Duration {
+	if entry.etag() != "" || entry.lastModified() != "" {
+		return 0
+	}
+
+	return freshness
+}
@@ -0,0 +1,105 @@
+package gcd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FileCache is a Cache storing one file per entry in a directory, so entries survive restarts.
+// Errors while reading or writing are treated as cache misses.
+type FileCache struct {
+	dir string
+}
+
+type fileCacheItem struct {
+	Key     string     `json:"key"`
+	Entry   CacheEntry `json:"entry"`
+	Expires time.Time  `json:"expires"` // zero means no expiry
+}
+
+// NewFileCache returns a cache storing its entries in dir, which is created if needed.
+func NewFileCache(dir string) (*FileCache, error) {
+	if dir == "" {
+		return nil, errors.New("empty cache directory")
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("os.MkdirAll: %w", err)
+	}
+
+	return &FileCache{dir: dir}, nil
+}
+
+func (c *FileCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+func (c *FileCache) Get(key string) (CacheEntry, bool) {
+	path := c.path(key)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return CacheEntry{}, false
+	}
+
+	var item fileCacheItem
+	if err := json.Unmarshal(data, &item); err != nil || item.Key != key {
+		return CacheEntry{}, false
+	}
+
+	if !item.Expires.IsZero() && time.Now().After(item.Expires) {
+		_ = os.Remove(path)
+
+		return CacheEntry{}, false
+	}
+
+	return item.Entry, true
+}
+
+func (c *FileCache) Set(key string, entry CacheEntry, ttl time.Duration) {
+	item := fileCacheItem{Key: key, Entry: entry}
+	if ttl > 0 {
+		item.Expires = time.Now().Add(ttl)
+	}
+
+	data, err := json.Marshal(item)
+	if err != nil {
+		return
+	}
+
+	_ = writeFileAtomic(c.path(key), data)
+}
+
+// writeFileAtomic writes data to a temporary file readable only by its owner and renames it to path, so
+// concurrent readers never see a partial write.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("os.CreateTemp: %w", err)
+	}
+
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+
+	return nil
+}
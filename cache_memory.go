@@ -0,0 +1,86 @@
+package gcd
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// MemoryCache is an in-memory Cache evicting the least recently used entries beyond its capacity.
+type MemoryCache struct {
+	mu sync.Mutex
+
+	capacity int
+	entries  map[string]*list.Element
+	order    *list.List // front is the most recently used
+}
+
+type memoryCacheItem struct {
+	key     string
+	entry   CacheEntry
+	expires time.Time // zero means no expiry
+}
+
+// NewMemoryCache returns a cache holding at most capacity entries. A capacity of zero or less means unbounded.
+func NewMemoryCache(capacity int) *MemoryCache {
+	return &MemoryCache{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+func (c *MemoryCache) Get(key string) (CacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return CacheEntry{}, false
+	}
+
+	item := elem.Value.(*memoryCacheItem)
+	if !item.expires.IsZero() && time.Now().After(item.expires) {
+		c.order.Remove(elem)
+		delete(c.entries, key)
+
+		return CacheEntry{}, false
+	}
+
+	c.order.MoveToFront(elem)
+
+	return item.entry, true
+}
+
+func (c *MemoryCache) Set(key string, entry CacheEntry, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	item := &memoryCacheItem{key: key, entry: entry}
+	if ttl > 0 {
+		item.expires = time.Now().Add(ttl)
+	}
+
+	if elem, ok := c.entries[key]; ok {
+		elem.Value = item
+		c.order.MoveToFront(elem)
+
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(item)
+
+	for c.capacity > 0 && c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*memoryCacheItem).key)
+	}
+}
+
+// Len returns the number of entries currently held.
+func (c *MemoryCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
@@ -0,0 +1,278 @@
+package gcd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCachePolicy(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 11, 13, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		header    http.Header
+		freshness time.Duration
+		storable  bool
+	}{
+		{"none", http.Header{}, time.Minute, true},
+		{"max-age", http.Header{"Cache-Control": {"public, max-age=300"}}, 5 * time.Minute, true},
+		{"no-store", http.Header{"Cache-Control": {"no-store"}, "Etag": {`"a"`}}, 0, false},
+		{"no-cache-etag", http.Header{"Cache-Control": {"no-cache"}, "Etag": {`"a"`}}, 0, true},
+		{"no-cache", http.Header{"Cache-Control": {"no-cache"}}, 0, false},
+		{"expires", http.Header{"Expires": {now.Add(time.Hour).Format(http.TimeFormat)}}, time.Hour, true},
+		{"expired", http.Header{"Expires": {now.Add(-time.Hour).Format(http.TimeFormat)}}, 0, false},
+		{"vary-star", http.Header{"Vary": {"Accept, *"}, "Etag": {`"a"`}}, 0, false},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			freshness, storable := cachePolicy(tt.header, now, time.Minute)
+			assert.Equal(t, tt.freshness, freshness, "freshness")
+			assert.Equal(t, tt.storable, storable, "storable")
+		})
+	}
+}
+
+func TestMemoryCache(t *testing.T) {
+	t.Parallel()
+
+	cache := NewMemoryCache(2)
+
+	cache.Set("a", CacheEntry{Body: []byte("a")}, 0)
+	cache.Set("b", CacheEntry{Body: []byte("b")}, 0)
+
+	_, ok := cache.Get("a")
+	require.True(t, ok)
+
+	cache.Set("c", CacheEntry{Body: []byte("c")}, 0)
+	assert.Equal(t, 2, cache.Len())
+
+	_, ok = cache.Get("b")
+	assert.False(t, ok, "least recently used entry is evicted")
+
+	entry, ok := cache.Get("a")
+	require.True(t, ok)
+	assert.Equal(t, []byte("a"), entry.Body)
+
+	cache.Set("d", CacheEntry{}, time.Nanosecond)
+	time.Sleep(time.Millisecond)
+
+	_, ok = cache.Get("d")
+	assert.False(t, ok, "expired")
+}
+
+func TestFileCache(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewFileCache("")
+	require.Error(t, err)
+
+	dir := t.TempDir()
+
+	cache, err := NewFileCache(dir)
+	require.NoError(t, err)
+
+	_, ok := cache.Get("https://example.org/api/issue/1/")
+	assert.False(t, ok)
+
+	cache.Set("https://example.org/api/issue/1/", CacheEntry{
+		Header: http.Header{"Etag": {`"v1"`}},
+		Body:   []byte(`{"series_name": "Superman"}`),
+	}, 0)
+
+	// a second cache on the same directory sees the entry
+	reopened, err := NewFileCache(dir)
+	require.NoError(t, err)
+
+	entry, ok := reopened.Get("https://example.org/api/issue/1/")
+	require.True(t, ok)
+	assert.Equal(t, `"v1"`, entry.Header.Get("ETag"))
+	assert.Equal(t, `{"series_name": "Superman"}`, string(entry.Body))
+
+	cache.Set("https://example.org/api/issue/2/", CacheEntry{}, time.Nanosecond)
+	time.Sleep(time.Millisecond)
+
+	_, ok = cache.Get("https://example.org/api/issue/2/")
+	assert.False(t, ok, "expired")
+}
+
+func TestAPI_Cache(t *testing.T) {
+	t.Parallel()
+
+	var (
+		calls       atomic.Int32
+		conditional atomic.Int32
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+
+		switch r.URL.Path {
+		case "/api/series/196803/":
+			w.Header().Set("Cache-Control", "max-age=3600")
+		case "/api/issue/2495111/":
+			w.Header().Set("Cache-Control", "no-cache")
+			w.Header().Set("ETag", `"v1"`)
+
+			if r.Header.Get("If-None-Match") == `"v1"` {
+				conditional.Add(1)
+				w.WriteHeader(http.StatusNotModified)
+
+				return
+			}
+		default:
+			w.Header().Set("Cache-Control", "no-store")
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintln(w, supermanSeriesInstance)
+	}))
+	t.Cleanup(server.Close)
+
+	stats := &CacheStats{}
+
+	api := API{
+		Prefix:     "http://" + server.Listener.Addr().String() + "/api/",
+		Cache:      NewMemoryCache(10),
+		CacheStats: stats,
+	}
+
+	ctx := context.Background()
+
+	t.Run("fresh", func(t *testing.T) {
+		for range 3 {
+			resp, err := api.SeriesInstance(ctx, 196803)
+			require.NoError(t, err)
+			assert.Equal(t, "Superman", resp.Name)
+		}
+
+		assert.EqualValues(t, 1, calls.Load())
+		assert.EqualValues(t, 2, stats.Hits.Load())
+		assert.EqualValues(t, 1, stats.Misses.Load())
+	})
+
+	t.Run("revalidate", func(t *testing.T) {
+		for range 2 {
+			resp, err := api.Issue(ctx, IssueReq{ID: 2495111})
+			require.NoError(t, err)
+			assert.Equal(t, "https://www.comics.org/api/series/196803/", resp.APIURL)
+		}
+
+		assert.EqualValues(t, 3, calls.Load())
+		assert.EqualValues(t, 1, conditional.Load())
+		assert.EqualValues(t, 1, stats.Revalidations.Load())
+	})
+
+	t.Run("no-store", func(t *testing.T) {
+		for range 2 {
+			_, err := api.SeriesInstance(ctx, 1)
+			require.NoError(t, err)
+		}
+
+		assert.EqualValues(t, 5, calls.Load())
+	})
+}
+
+func TestAPI_CacheKey(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+
+		w.Header().Set("Cache-Control", "max-age=3600")
+		w.Header().Set("Set-Cookie", "csrftoken=secret; Path=/")
+
+		switch r.URL.Path {
+		case "/api/series/1/":
+			w.Header().Set("Vary", "*")
+		case "/api/series/2/":
+			w.Header().Set("Vary", "User-Agent")
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintln(w, supermanSeriesInstance)
+	}))
+	t.Cleanup(server.Close)
+
+	dir := t.TempDir()
+
+	fileCache, err := NewFileCache(dir)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	for _, cache := range []Cache{NewMemoryCache(10), fileCache} {
+		calls.Store(0)
+
+		anonymous := API{
+			Prefix: "http://" + server.Listener.Addr().String() + "/api/",
+			Cache:  cache,
+		}
+
+		fetch := func(api API, id int) {
+			t.Helper()
+
+			_, err := api.SeriesInstance(ctx, id)
+			require.NoError(t, err)
+		}
+
+		fetch(anonymous, 196803)
+		fetch(anonymous, 196803)
+		assert.EqualValues(t, 1, calls.Load())
+
+		// other sessions and headers do not see the anonymous response
+		session := anonymous
+		session.SessionID = "abc"
+		fetch(session, 196803)
+
+		french := anonymous
+		french.Headers = http.Header{"Accept-Language": {"fr"}}
+		fetch(french, 196803)
+		fetch(french, 196803)
+		assert.EqualValues(t, 3, calls.Load())
+
+		fetch(anonymous, 1)
+		fetch(anonymous, 1)
+		assert.EqualValues(t, 5, calls.Load(), "Vary: * is never stored")
+
+		fetch(anonymous, 2)
+		fetch(anonymous, 2)
+		assert.EqualValues(t, 6, calls.Load())
+
+		other := anonymous
+		other.UserAgent = "Other/1.0"
+		fetch(other, 2)
+		assert.EqualValues(t, 7, calls.Load(), "Vary: User-Agent with another user agent")
+
+		entry, ok := cache.Get(anonymous.prefix() + "series/196803/")
+		require.True(t, ok)
+		assert.Empty(t, entry.Header.Values("Set-Cookie"))
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "secret")
+	}
+}
@@ -0,0 +1,91 @@
+package gcd
+
+import (
+	"slices"
+	"strings"
+)
+
+// CharacterAppearance is a single entry of StorySet.Characters, e.g. "Superman [Clark Kent; Kal-El] (flashback)".
+type CharacterAppearance struct {
+	Name       string
+	AlterEgos  []string              // names in brackets, e.g. "Clark Kent" and "Kal-El"
+	Qualifiers []string              // annotations in parentheses, e.g. "flashback", "image", "cameo"
+	Members    []CharacterAppearance // set instead of AlterEgos for groups, e.g. "Justice League [Superman [Clark Kent]; Batman]"
+}
+
+// IsGroup reports whether the appearance is a group or team listing its members.
+func (c CharacterAppearance) IsGroup() bool {
+	return len(c.Members) > 0
+}
+
+// AsGroup returns the appearance with its alter egos turned into members, for entries the caller knows to be
+// a team, e.g. "Justice League [Superman; Batman]".
+func (c CharacterAppearance) AsGroup() CharacterAppearance {
+	c.Members = slices.Clone(c.Members)
+
+	for _, alias := range c.AlterEgos {
+		c.Members = append(c.Members, CharacterAppearance{Name: alias})
+	}
+
+	c.AlterEgos = nil
+
+	return c
+}
+
+// ParseCharacters parses a gcd character list. Semicolons nested inside brackets or parentheses do not
+// split entries.
+//
+// The gcd uses brackets both for alter egos and for the members of a team, so a bracketed list is only
+// recognized as members when one of its entries carries its own brackets or parentheses. A plain team such as
+// "Justice League [Superman; Batman]" cannot be told apart from "Superman [Clark Kent; Kal-El]" and is
+// returned with alter egos; use AsGroup when the entry is known to be a team.
+func ParseCharacters(s string) []CharacterAppearance {
+	var appearances []CharacterAppearance
+
+	for _, entry := range splitTopLevel(s, ';') {
+		if entry == "" {
+			continue
+		}
+
+		appearances = append(appearances, parseCharacter(entry))
+	}
+
+	return appearances
+}
+
+func parseCharacter(entry string) CharacterAppearance {
+	name, groups := splitGroups(entry)
+
+	appearance := CharacterAppearance{Name: name}
+
+	for _, group := range groups {
+		if group.open == '(' {
+			for _, qualifier := range splitTopLevel(group.text, ';') {
+				if qualifier != "" {
+					appearance.Qualifiers = append(appearance.Qualifiers, qualifier)
+				}
+			}
+
+			continue
+		}
+
+		if strings.ContainsAny(group.text, "[(") {
+			appearance.Members = append(appearance.Members, ParseCharacters(group.text)...)
+
+			continue
+		}
+
+		for _, alias := range splitTopLevel(group.text, ';') {
+			if alias != "" {
+				appearance.AlterEgos = append(appearance.AlterEgos, alias)
+			}
+		}
+	}
+
+	return appearance
+}
+
+// CharacterAppearances parses the Characters field of the story.
+func (s StorySet) CharacterAppearances() []CharacterAppearance {
+	return ParseCharacters(s.Characters)
+}
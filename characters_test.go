@@ -0,0 +1,113 @@
+package gcd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCharacters(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected []CharacterAppearance
+	}{
+		{
+			"empty",
+			"",
+			nil,
+		},
+		{
+			"alter-egos-and-qualifiers",
+			"Superman [Clark Kent; Kal-El]; Martha Kent (flashback); Superman [Jon Kent] (image)",
+			[]CharacterAppearance{
+				{Name: "Superman", AlterEgos: []string{"Clark Kent", "Kal-El"}},
+				{Name: "Martha Kent", Qualifiers: []string{"flashback"}},
+				{Name: "Superman", AlterEgos: []string{"Jon Kent"}, Qualifiers: []string{"image"}},
+			},
+		},
+		{
+			"several-qualifiers",
+			"Batman [Bruce Wayne] (cameo; flashback); Robin (image)",
+			[]CharacterAppearance{
+				{Name: "Batman", AlterEgos: []string{"Bruce Wayne"}, Qualifiers: []string{"cameo", "flashback"}},
+				{Name: "Robin", Qualifiers: []string{"image"}},
+			},
+		},
+		{
+			"description",
+			"LL-01 (Lex Luthor hologram); Parasite children",
+			[]CharacterAppearance{
+				{Name: "LL-01", Qualifiers: []string{"Lex Luthor hologram"}},
+				{Name: "Parasite children"},
+			},
+		},
+		{
+			"group",
+			"Justice League [Superman [Clark Kent]; Batman [Bruce Wayne] (cameo); Aquaman] (flashback); Lois Lane",
+			[]CharacterAppearance{
+				{
+					Name:       "Justice League",
+					Qualifiers: []string{"flashback"},
+					Members: []CharacterAppearance{
+						{Name: "Superman", AlterEgos: []string{"Clark Kent"}},
+						{Name: "Batman", AlterEgos: []string{"Bruce Wayne"}, Qualifiers: []string{"cameo"}},
+						{Name: "Aquaman"},
+					},
+				},
+				{Name: "Lois Lane"},
+			},
+		},
+		{
+			"plain-group",
+			"Justice League [Superman; Batman; Wonder Woman]",
+			[]CharacterAppearance{
+				{Name: "Justice League", AlterEgos: []string{"Superman", "Batman", "Wonder Woman"}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, ParseCharacters(tt.input))
+		})
+	}
+}
+
+func TestCharacterAppearance_AsGroup(t *testing.T) {
+	t.Parallel()
+
+	appearances := ParseCharacters("Justice League [Superman; Batman; Wonder Woman] (cameo)")
+	require.Len(t, appearances, 1)
+	assert.False(t, appearances[0].IsGroup(), "plain bracket lists read as alter egos")
+
+	team := appearances[0].AsGroup()
+	assert.True(t, team.IsGroup())
+	assert.Equal(t, CharacterAppearance{
+		Name:       "Justice League",
+		Qualifiers: []string{"cameo"},
+		Members:    []CharacterAppearance{{Name: "Superman"}, {Name: "Batman"}, {Name: "Wonder Woman"}},
+	}, team)
+	assert.Equal(t, []string{"Superman", "Batman", "Wonder Woman"}, appearances[0].AlterEgos, "the original is unchanged")
+}
+
+func TestStorySet_CharacterAppearances(t *testing.T) {
+	t.Parallel()
+
+	var issue IssueResp
+	require.NoError(t, json.Unmarshal([]byte(superman2023_1Issue), &issue))
+
+	characters := issue.StorySet[1].CharacterAppearances()
+	require.Len(t, characters, 27)
+
+	assert.Equal(t, CharacterAppearance{Name: "Superman", AlterEgos: []string{"Clark Kent", "Kal-El"}}, characters[0])
+	assert.Equal(t, CharacterAppearance{Name: "Steel", AlterEgos: []string{"Natasha Irons"}, Qualifiers: []string{"image"}}, characters[24])
+	assert.False(t, characters[0].IsGroup())
+}
@@ -0,0 +1,306 @@
+package gcd
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Client is an API configured and validated by New. Every API method is available on it.
+type Client struct {
+	API
+
+	transport *TransportOptions // set by WithTransport, turned into the HTTP client by New
+}
+
+// Option configures a Client built by New.
+type Option func(*Client) error
+
+// New returns a Client for the gcd api at DefaultPrefix, or WithPrefix, configured by opts.
+func New(opts ...Option) (*Client, error) {
+	c := &Client{}
+
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.Prefix == "" {
+		c.Prefix = DefaultPrefix
+	}
+
+	if c.transport != nil {
+		if c.Client != nil {
+			return nil, errors.New("WithTransport and WithHTTPClient cannot be combined")
+		}
+
+		transport, err := NewTransport(*c.transport)
+		if err != nil {
+			return nil, err
+		}
+
+		c.Client = &http.Client{Transport: transport}
+	}
+
+	if c.Credentials != nil && c.Sessions == nil {
+		// without a store, a session renewed by one call is lost to the next
+		c.Sessions = NewMemorySessionStore()
+
+		if c.SessionID != "" {
+			if err := c.Sessions.Save(Session{ID: c.SessionID}); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (c *Client) validate() error {
+	if err := validatePrefix(c.Prefix); err != nil {
+		return err
+	}
+
+	if c.Timeout < 0 {
+		return errors.New("timeout cannot be negative")
+	}
+
+	if c.CacheTTL < 0 {
+		return errors.New("cache TTL cannot be negative")
+	}
+
+	if c.Retry != nil && (c.Retry.MaxAttempts < 0 || c.Retry.BaseDelay < 0 || c.Retry.MaxDelay < 0) {
+		return errors.New("retry policy cannot have negative values")
+	}
+
+	return nil
+}
+
+// validatePrefix checks prefix is an absolute http or https URL without query or fragment.
+func validatePrefix(prefix string) error {
+	u, err := url.Parse(prefix)
+	if err != nil {
+		return fmt.Errorf("invalid prefix: %w", err)
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid prefix %q: scheme must be http or https", prefix)
+	}
+
+	if u.Host == "" {
+		return fmt.Errorf("invalid prefix %q: missing host", prefix)
+	}
+
+	if u.RawQuery != "" || u.Fragment != "" {
+		return fmt.Errorf("invalid prefix %q: query and fragment are not allowed", prefix)
+	}
+
+	return nil
+}
+
+// WithPrefix sets the base URL of the api, e.g. a mirror or a test server.
+func WithPrefix(prefix string) Option {
+	return func(c *Client) error {
+		c.Prefix = strings.TrimSuffix(prefix, "/")
+
+		return nil
+	}
+}
+
+// WithHTTPClient sets the client sending the requests. It must speak HTTP/2 to reach the gcd api.
+func WithHTTPClient(client HTTPDoer) Option {
+	return func(c *Client) error {
+		if client == nil {
+			return errors.New("nil HTTP client")
+		}
+
+		c.Client = client
+
+		return nil
+	}
+}
+
+// WithTransport builds the HTTP client with NewTransport(opts).
+func WithTransport(opts TransportOptions) Option {
+	return func(c *Client) error {
+		c.transport = &opts
+
+		return nil
+	}
+}
+
+// WithTimeout limits how long a call may take, retries included.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) error {
+		c.Timeout = timeout
+
+		return nil
+	}
+}
+
+// WithRetry sets the retry policy, e.g. DefaultRetryPolicy().
+func WithRetry(policy *RetryPolicy) Option {
+	return func(c *Client) error {
+		c.Retry = policy
+
+		return nil
+	}
+}
+
+// WithLimiter sets the rate limiter shared by all requests.
+func WithLimiter(limiter *RateLimiter) Option {
+	return func(c *Client) error {
+		c.Limiter = limiter
+
+		return nil
+	}
+}
+
+// WithSessionLimiter sets the rate limiter used by authenticated requests.
+func WithSessionLimiter(limiter *RateLimiter) Option {
+	return func(c *Client) error {
+		c.SessionLimiter = limiter
+
+		return nil
+	}
+}
+
+// WithCache caches responses in cache, fresh for ttl when the server does not say otherwise.
+func WithCache(cache Cache, ttl time.Duration) Option {
+	return func(c *Client) error {
+		c.Cache = cache
+		c.CacheTTL = ttl
+
+		return nil
+	}
+}
+
+// WithCacheStats counts cache outcomes in stats.
+func WithCacheStats(stats *CacheStats) Option {
+	return func(c *Client) error {
+		c.CacheStats = stats
+
+		return nil
+	}
+}
+
+// WithLogger logs every call to logger.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Client) error {
+		c.Logger = logger
+
+		return nil
+	}
+}
+
+// WithTracer traces every call and HTTP attempt.
+func WithTracer(tracer Tracer) Option {
+	return func(c *Client) error {
+		c.Tracer = tracer
+
+		return nil
+	}
+}
+
+// WithMetrics reports measurements to metrics, e.g. NewPrometheusMetrics().
+func WithMetrics(metrics Metrics) Option {
+	return func(c *Client) error {
+		c.Metrics = metrics
+
+		return nil
+	}
+}
+
+// WithMiddleware appends middlewares around the HTTP client.
+func WithMiddleware(mw ...Middleware) Option {
+	return func(c *Client) error {
+		c.Use(mw...)
+
+		return nil
+	}
+}
+
+// WithUserAgent identifies the application ahead of the library's own user agent.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) error {
+		c.UserAgent = userAgent
+
+		return nil
+	}
+}
+
+// WithHeader adds a header sent with every request.
+func WithHeader(key, value string) Option {
+	return func(c *Client) error {
+		if c.Headers == nil {
+			c.Headers = make(http.Header)
+		}
+
+		c.Headers.Add(key, value)
+
+		return nil
+	}
+}
+
+// WithSessionID sends requests with the given gcdsessionid cookie.
+func WithSessionID(sessionID string) Option {
+	return func(c *Client) error {
+		c.SessionID = sessionID
+
+		return nil
+	}
+}
+
+// WithCredentials logs in again with the credentials from provider when the session expires.
+func WithCredentials(provider CredentialsProvider) Option {
+	return func(c *Client) error {
+		c.Credentials = provider
+
+		return nil
+	}
+}
+
+// WithSessionStore keeps the session in store.
+func WithSessionStore(store SessionStore) Option {
+	return func(c *Client) error {
+		c.Sessions = store
+
+		return nil
+	}
+}
+
+// WithStrict reports payloads with unknown or missing fields as *SchemaDriftError.
+func WithStrict() Option {
+	return func(c *Client) error {
+		c.Strict = true
+
+		return nil
+	}
+}
+
+// WithPrefetch fetches the next page concurrently while iterating over paginated results.
+func WithPrefetch() Option {
+	return func(c *Client) error {
+		c.Prefetch = true
+
+		return nil
+	}
+}
+
+// WithMaxResponseSize limits response bodies to size bytes; a negative size disables the limit.
+func WithMaxResponseSize(size int64) Option {
+	return func(c *Client) error {
+		c.MaxResponseSize = size
+
+		return nil
+	}
+}
@@ -0,0 +1,157 @@
+package gcd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	client, err := New()
+	require.NoError(t, err)
+	assert.Equal(t, DefaultPrefix, client.Prefix)
+
+	retry := DefaultRetryPolicy()
+	cache := NewMemoryCache(10)
+
+	client, err = New(
+		WithPrefix("https://gcd.example.com/api/"),
+		WithTimeout(time.Minute),
+		WithRetry(retry),
+		WithCache(cache, time.Hour),
+		WithUserAgent("MyApp/1.0"),
+		WithHeader("From", "dev@example.com"),
+		WithCredentials(StaticCredentials{Username: "jane", Password: "secret"}),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "https://gcd.example.com/api", client.Prefix)
+	assert.Equal(t, time.Minute, client.Timeout)
+	assert.Same(t, retry, client.Retry)
+	assert.Same(t, cache, client.Cache)
+	assert.Equal(t, time.Hour, client.CacheTTL)
+	assert.Equal(t, "MyApp/1.0", client.UserAgent)
+	assert.Equal(t, "dev@example.com", client.Headers.Get("From"))
+	assert.NotNil(t, client.Sessions, "a store keeps renewed sessions")
+}
+
+func TestNew_sessionAndCredentials(t *testing.T) {
+	t.Parallel()
+
+	gcd := newFakeGCD(t)
+
+	loggedIn := API{Prefix: gcd.prefix()}
+	require.NoError(t, loggedIn.Login(context.Background(), "jane", "secret"))
+
+	client, err := New(
+		WithPrefix(gcd.prefix()),
+		WithSessionID(loggedIn.SessionID),
+		WithCredentials(StaticCredentials{Username: "jane", Password: "secret"}),
+	)
+	require.NoError(t, err)
+
+	session, err := client.Sessions.Load()
+	require.NoError(t, err)
+	assert.Equal(t, loggedIn.SessionID, session.ID, "the store starts with the session")
+
+	_, err = client.Issue(context.Background(), IssueReq{ID: 2495111})
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, gcd.logins.Load(), "no login while the session is valid")
+}
+
+func TestNew_invalid(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string][]Option{
+		"relative prefix":  {WithPrefix("comics.org/api")},
+		"ftp prefix":       {WithPrefix("ftp://comics.org/api")},
+		"prefix query":     {WithPrefix("https://comics.org/api?format=json")},
+		"bad prefix":       {WithPrefix("https://comics.org:port/api")},
+		"negative timeout": {WithTimeout(-time.Second)},
+		"negative ttl":     {WithCache(NewMemoryCache(1), -time.Second)},
+		"negative retry":   {WithRetry(&RetryPolicy{MaxAttempts: -1})},
+		"nil client":       {WithHTTPClient(nil)},
+		"transport and client": {
+			WithTransport(TransportOptions{}),
+			WithHTTPClient(&http.Client{}),
+		},
+		"bad transport": {WithTransport(TransportOptions{MaxIdleConns: -1})},
+	}
+
+	for name, opts := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := New(opts...)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestClient(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/series/196803/" {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintln(w, supermanSeriesInstance)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := New(WithPrefix(server.URL+"/api"), WithTransport(TransportOptions{}))
+	require.NoError(t, err)
+
+	series, err := client.SeriesInstance(context.Background(), 196803)
+	require.NoError(t, err)
+	assert.Equal(t, "Superman", series.Name)
+
+	_, err = client.SeriesInstance(context.Background(), 0)
+	require.Error(t, err)
+}
+
+func TestAPI_SeriesInstance_emptyPrefix(t *testing.T) {
+	t.Parallel()
+
+	var requested string
+
+	api := API{Client: HTTPDoerFunc(func(r *http.Request) (*http.Response, error) {
+		requested = r.URL.String()
+
+		return nil, fmt.Errorf("offline")
+	})}
+
+	assert.NotPanics(t, func() {
+		_, err := api.SeriesInstance(context.Background(), 196803)
+		require.Error(t, err)
+	})
+	assert.Equal(t, DefaultPrefix+"/series/196803/", requested)
+}
+
+func TestAPI_Timeout(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := New(WithPrefix(server.URL+"/api"), WithTimeout(50*time.Millisecond))
+	require.NoError(t, err)
+
+	start := time.Now()
+	_, err = client.Issue(context.Background(), IssueReq{ID: 1})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
@@ -0,0 +1,176 @@
+package gcd
+
+import "strings"
+
+// Credit is a single creator entry of a gcd credit field, e.g. "Jamal Campbell (credited) (signed as JC Pryce14)".
+type Credit struct {
+	Name       string   // empty when the creator is unknown ("?") or the credit is "typeset"
+	CreditedAs string   // name printed in the credits when it differs, from "Name [as Other]"
+	Credited   bool     // "(credited)": the creator is named in the published credits
+	SignedAs   string   // "(signed as X)"
+	Signed     bool     // "(signed)" or "(signed as X)"
+	Roles      []string // any other qualifiers, e.g. "assistant editor"
+	Uncertain  bool     // "?": the attribution is a guess
+	Typeset    bool     // lettering was typeset rather than done by a person
+}
+
+// ParseCredits parses a gcd credit field such as StorySet.Pencils or IssueResp.Editing.
+func ParseCredits(s string) []Credit {
+	var credits []Credit
+
+	for _, entry := range splitTopLevel(s, ';') {
+		if entry == "" {
+			continue
+		}
+
+		credits = append(credits, parseCredit(entry))
+	}
+
+	return credits
+}
+
+func parseCredit(entry string) Credit {
+	var credit Credit
+
+	name, groups := splitGroups(entry)
+
+	for _, group := range groups {
+		if group.open == '[' {
+			if as, ok := strings.CutPrefix(group.text, "as "); ok {
+				credit.CreditedAs = strings.TrimSpace(as)
+			}
+
+			continue
+		}
+
+		qualifier := group.text
+		switch lower := strings.ToLower(qualifier); {
+		case lower == "credited":
+			credit.Credited = true
+		case lower == "signed":
+			credit.Signed = true
+		case strings.HasPrefix(lower, "signed as "):
+			credit.Signed = true
+			credit.SignedAs = strings.TrimSpace(qualifier[len("signed as "):])
+		case lower == "?":
+			credit.Uncertain = true
+		case lower != "":
+			credit.Roles = append(credit.Roles, qualifier)
+		}
+	}
+
+	if trimmed, ok := strings.CutSuffix(name, "?"); ok {
+		credit.Uncertain = true
+		name = strings.TrimSpace(trimmed)
+	}
+
+	if strings.EqualFold(name, "typeset") {
+		credit.Typeset = true
+		name = ""
+	}
+
+	credit.Name = name
+
+	return credit
+}
+
+// StoryCredits holds the parsed credit fields of a StorySet.
+type StoryCredits struct {
+	Script  []Credit
+	Pencils []Credit
+	Inks    []Credit
+	Colors  []Credit
+	Letters []Credit
+	Editing []Credit
+}
+
+// Credits parses every credit field of the story.
+func (s StorySet) Credits() StoryCredits {
+	return StoryCredits{
+		Script:  ParseCredits(s.Script),
+		Pencils: ParseCredits(s.Pencils),
+		Inks:    ParseCredits(s.Inks),
+		Colors:  ParseCredits(s.Colors),
+		Letters: ParseCredits(s.Letters),
+		Editing: ParseCredits(s.Editing),
+	}
+}
+
+// EditingCredits parses the issue level editing credits.
+func (r IssueResp) EditingCredits() []Credit {
+	return ParseCredits(r.Editing)
+}
+
+// splitTopLevel splits s on sep, ignoring separators nested inside parentheses or brackets.
+// Every part is trimmed of surrounding whitespace.
+func splitTopLevel(s string, sep byte) []string {
+	var (
+		parts []string
+		depth int
+		start int
+	)
+
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '(' || c == '[':
+			depth++
+		case c == ')' || c == ']':
+			if depth > 0 {
+				depth--
+			}
+		case c == sep && depth == 0:
+			parts = append(parts, strings.TrimSpace(s[start:i]))
+			start = i + 1
+		}
+	}
+
+	if rest := strings.TrimSpace(s[start:]); rest != "" || len(parts) > 0 {
+		parts = append(parts, rest)
+	}
+
+	return parts
+}
+
+type group struct {
+	open byte // '(' or '['
+	text string
+}
+
+// splitGroups separates the text outside top level parentheses and brackets from the groups themselves.
+// An unbalanced group runs until the end of s.
+func splitGroups(s string) (string, []group) {
+	var (
+		outside strings.Builder
+		groups  []group
+		depth   int
+		open    byte
+		start   int
+	)
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+
+		switch {
+		case c == '(' || c == '[':
+			if depth == 0 {
+				open = c
+				start = i + 1
+			}
+
+			depth++
+		case (c == ')' || c == ']') && depth > 0:
+			depth--
+			if depth == 0 {
+				groups = append(groups, group{open: open, text: strings.TrimSpace(s[start:i])})
+			}
+		case depth == 0:
+			outside.WriteByte(c)
+		}
+	}
+
+	if depth > 0 {
+		groups = append(groups, group{open: open, text: strings.TrimSpace(s[start:])})
+	}
+
+	return strings.Join(strings.Fields(outside.String()), " "), groups
+}
@@ -0,0 +1,96 @@
+package gcd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCredits(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected []Credit
+	}{
+		{
+			"empty",
+			"",
+			nil,
+		},
+		{
+			"signed-as",
+			"Jamal Campbell (credited) (signed as JC Pryce14)",
+			[]Credit{{Name: "Jamal Campbell", Credited: true, Signed: true, SignedAs: "JC Pryce14"}},
+		},
+		{
+			"roles",
+			"Jillian Grant (credited) (assistant editor); Paul Kaminski (credited) (editor)",
+			[]Credit{
+				{Name: "Jillian Grant", Credited: true, Roles: []string{"assistant editor"}},
+				{Name: "Paul Kaminski", Credited: true, Roles: []string{"editor"}},
+			},
+		},
+		{
+			"unknown",
+			"?",
+			[]Credit{{Uncertain: true}},
+		},
+		{
+			"unknown-typeset",
+			"?; typeset",
+			[]Credit{{Uncertain: true}, {Typeset: true}},
+		},
+		{
+			"uncertain-name",
+			"Jack Kirby ?; Joe Simon (?)",
+			[]Credit{{Name: "Jack Kirby", Uncertain: true}, {Name: "Joe Simon", Uncertain: true}},
+		},
+		{
+			"credited-as",
+			"Stan Lee [as Stan; The Man] (credited) (signed)",
+			[]Credit{{Name: "Stan Lee", CreditedAs: "Stan; The Man", Credited: true, Signed: true}},
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, ParseCredits(tt.input))
+		})
+	}
+}
+
+func TestStorySet_Credits(t *testing.T) {
+	t.Parallel()
+
+	var issue IssueResp
+	require.NoError(t, json.Unmarshal([]byte(superman2023_1Issue), &issue))
+
+	editing := issue.EditingCredits()
+	require.Len(t, editing, 2)
+	assert.Equal(t, "Paul Kaminski", editing[1].Name)
+	assert.Equal(t, []string{"editor"}, editing[1].Roles)
+
+	cover := issue.StorySet[0].Credits()
+	require.Len(t, cover.Pencils, 1)
+	assert.Equal(t, "JC Pryce14", cover.Pencils[0].SignedAs)
+	assert.Equal(t, []Credit{{Uncertain: true}}, cover.Letters)
+	assert.Empty(t, cover.Script)
+
+	credits := issue.StorySet[2].Credits()
+	assert.Equal(t, []Credit{{Uncertain: true}, {Typeset: true}}, credits.Letters)
+}
+
+func TestSplitTopLevel(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, splitTopLevel("", ';'))
+	assert.Equal(t, []string{"a", "b [c; d]", "e (f; g)"}, splitTopLevel("a; b [c; d]; e (f; g)", ';'))
+	assert.Equal(t, []string{"a", ""}, splitTopLevel("a;", ';'))
+}
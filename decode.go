@@ -0,0 +1,112 @@
+package gcd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+)
+
+// DefaultMaxResponseSize is the response body limit used when API.MaxResponseSize is zero.
+const DefaultMaxResponseSize = 32 << 20
+
+// ResponseTooLargeError is returned when a response body exceeds API.MaxResponseSize.
+type ResponseTooLargeError struct {
+	URL   string
+	Limit int64
+}
+
+func (e *ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("gcd: response from %s exceeds %d bytes", e.URL, e.Limit)
+}
+
+func (a API) maxResponseSize() int64 {
+	if a.MaxResponseSize == 0 {
+		return DefaultMaxResponseSize
+	}
+
+	return a.MaxResponseSize
+}
+
+func (a API) decodeResponse(ctx context.Context, resp *http.Response, v any) error {
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return newAPIError(resp)
+	}
+
+	var url string
+	if resp.Request != nil && resp.Request.URL != nil {
+		url = resp.Request.URL.String()
+	}
+
+	body, sizeHint := io.Reader(resp.Body), resp.ContentLength
+	if limit := a.maxResponseSize(); limit > 0 {
+		body = &limitedReader{r: resp.Body, n: limit, err: &ResponseTooLargeError{URL: url, Limit: limit}}
+		sizeHint = min(sizeHint, limit)
+	}
+
+	data, err := readBody(body, sizeHint)
+	if err != nil {
+		return err
+	}
+
+	// data is read once and only here, so the decoded values keep their raw payload in it instead of a copy
+	if err := unmarshalOwned(data, v); err != nil {
+		return fmt.Errorf("json.Unmarshal: %w", err)
+	}
+
+	if a.Strict {
+		return checkSchema(url, data, v)
+	}
+
+	if a.logEnabled(ctx, slog.LevelDebug) {
+		a.logPayload(ctx, url, data, v)
+	}
+
+	return nil
+}
+
+// readBody reads r whole into a single buffer, allocated up front when sizeHint, the Content-Length of the
+// response, is known.
+func readBody(r io.Reader, sizeHint int64) ([]byte, error) {
+	var buf bytes.Buffer
+	if sizeHint > 0 {
+		buf.Grow(int(sizeHint) + bytes.MinRead) // ReadFrom wants room to see EOF
+	}
+
+	if _, err := buf.ReadFrom(r); err != nil {
+		return nil, fmt.Errorf("bytes.Buffer.ReadFrom: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// limitedReader reads at most n bytes from r and fails with err if r holds more.
+type limitedReader struct {
+	r   io.Reader
+	n   int64
+	err error
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.n <= 0 {
+		var probe [1]byte
+
+		n, err := l.r.Read(probe[:])
+		if n > 0 {
+			return 0, l.err
+		}
+
+		return 0, err
+	}
+
+	if int64(len(p)) > l.n {
+		p = p[:l.n]
+	}
+
+	n, err := l.r.Read(p)
+	l.n -= int64(n)
+
+	return n, err
+}
@@ -0,0 +1,191 @@
+package gcd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPI_MaxResponseSize(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, supermanSeriesInstance)
+	}))
+	t.Cleanup(server.Close)
+
+	api := API{
+		Prefix:          "http://" + server.Listener.Addr().String() + "/api/",
+		MaxResponseSize: 100,
+	}
+
+	_, err := api.SeriesInstance(context.Background(), 196803)
+
+	var tooLarge *ResponseTooLargeError
+	require.ErrorAs(t, err, &tooLarge)
+	assert.EqualValues(t, 100, tooLarge.Limit)
+	assert.Equal(t, api.Prefix+"series/196803/", tooLarge.URL)
+
+	api.Strict = true
+
+	_, err = api.SeriesInstance(context.Background(), 196803)
+	require.ErrorAs(t, err, &tooLarge, "strict mode")
+
+	api.Strict = false
+	api.MaxResponseSize = int64(len(supermanSeriesInstance))
+
+	resp, err := api.SeriesInstance(context.Background(), 196803)
+	require.NoError(t, err, "body exactly at the limit")
+	assert.Equal(t, "Superman", resp.Name)
+
+	api.MaxResponseSize = -1
+
+	_, err = api.SeriesInstance(context.Background(), 196803)
+	require.NoError(t, err, "limit disabled")
+}
+
+func TestLimitedReader(t *testing.T) {
+	t.Parallel()
+
+	errTooLarge := fmt.Errorf("too large")
+
+	var buf bytes.Buffer
+
+	n, err := buf.ReadFrom(&limitedReader{r: strings.NewReader("12345"), n: 5, err: errTooLarge})
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, n)
+
+	buf.Reset()
+
+	_, err = buf.ReadFrom(&limitedReader{r: strings.NewReader("123456"), n: 5, err: errTooLarge})
+	require.ErrorIs(t, err, errTooLarge)
+}
+
+func TestAPI_decodeResponse_exactRaw(t *testing.T) {
+	t.Parallel()
+
+	page := `{
+  "next": null,
+  "results": [
+    {"name": "Superman",  "year_began": 1939, "keywords": ["kryptonian"]},
+    {"name": "Batman", "year_began": 1940}
+  ],
+  "count": 2
+}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, page)
+	}))
+	t.Cleanup(server.Close)
+
+	apis := map[string]API{
+		"default": {},
+		"strict":  {Strict: true},
+		"debug":   {Logger: slog.New(slog.NewJSONHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelDebug}))},
+	}
+
+	for name, api := range apis {
+		api.Prefix = "http://" + server.Listener.Addr().String() + "/api/"
+
+		resp, err := api.Series(context.Background(), SeriesReq{})
+		if name == "strict" {
+			var drift *SchemaDriftError
+			require.ErrorAs(t, err, &drift, "keywords is unknown")
+		} else {
+			require.NoError(t, err, name)
+		}
+
+		assert.Equal(t, page, string(resp.Raw()), "%s: the exact payload", name)
+		require.Len(t, resp.Results, 2, name)
+		assert.Equal(t, `{"name": "Superman",  "year_began": 1939, "keywords": ["kryptonian"]}`, string(resp.Results[0].Raw()), name)
+		assert.Equal(t, `["kryptonian"]`, string(resp.Results[0].Extra()["keywords"]), name)
+	}
+}
+
+// largeSeriesPage builds a series listing with hundreds of active issues per series.
+func largeSeriesPage(tb testing.TB) []byte {
+	tb.Helper()
+
+	resp := struct {
+		Count   int              `json:"count"`
+		Next    *string          `json:"next"`
+		Results []map[string]any `json:"results"`
+	}{Count: 100}
+
+	for i := range resp.Count {
+		issues := make([]string, 500)
+		descriptors := make([]string, len(issues))
+
+		for j := range issues {
+			issues[j] = fmt.Sprintf("https://www.comics.org/api/issue/%d/", i*1000+j)
+			descriptors[j] = fmt.Sprintf("%d [Regular Cover]", j+1)
+		}
+
+		resp.Results = append(resp.Results, map[string]any{
+			"api_url":           fmt.Sprintf("https://www.comics.org/api/series/%d/", i),
+			"name":              "Superman",
+			"active_issues":     issues,
+			"issue_descriptors": descriptors,
+			"year_began":        1939,
+			"year_ended":        nil,
+			"publisher":         "https://www.comics.org/api/publisher/54/",
+		})
+	}
+
+	data, err := json.Marshal(resp)
+	require.NoError(tb, err)
+
+	return data
+}
+
+func BenchmarkDecode(b *testing.B) {
+	data := largeSeriesPage(b)
+
+	// the previous approach: the body is read whole, and the outermost UnmarshalJSON copies it again
+	b.Run("copy", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(data)))
+
+		for range b.N {
+			body, err := io.ReadAll(bytes.NewReader(data))
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			var resp SeriesResp
+			if err := json.Unmarshal(body, &resp); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("decodeResponse", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(data)))
+
+		for range b.N {
+			resp := &http.Response{
+				StatusCode:    http.StatusOK,
+				Body:          io.NopCloser(bytes.NewReader(data)),
+				ContentLength: int64(len(data)),
+			}
+
+			var v SeriesResp
+			if err := (API{}).decodeResponse(context.Background(), resp, &v); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
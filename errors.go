@@ -0,0 +1,89 @@
+package gcd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+var (
+	ErrNotFound     = errors.New("gcd: not found")
+	ErrRateLimited  = errors.New("gcd: rate limited")
+	ErrUnauthorized = errors.New("gcd: unauthorized")
+	ErrServerError  = errors.New("gcd: server error")
+)
+
+// maxErrorBodySize is how much of a non-2xx response body is kept on APIError.
+const maxErrorBodySize = 512
+
+// APIError is returned when the gcd api answers with a non-2xx status code.
+// Use errors.Is with the Err* sentinels to classify it, or errors.As to inspect it.
+type APIError struct {
+	StatusCode int
+	URL        string
+	RetryAfter time.Duration // zero if the server did not send a Retry-After header
+	Body       string        // first bytes of the response body
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("gcd: unexpected status code %d for %s", e.StatusCode, e.URL)
+}
+
+func (e *APIError) Unwrap() error {
+	switch {
+	case e.StatusCode == http.StatusNotFound || e.StatusCode == http.StatusGone:
+		return ErrNotFound
+	case e.StatusCode == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden:
+		return ErrUnauthorized
+	case e.StatusCode >= http.StatusInternalServerError:
+		return ErrServerError
+	}
+
+	return nil
+}
+
+func newAPIError(resp *http.Response) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+	}
+
+	if resp.Request != nil && resp.Request.URL != nil {
+		apiErr.URL = resp.Request.URL.String()
+	}
+
+	if resp.Body != nil {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		apiErr.Body = string(data)
+	}
+
+	return apiErr
+}
+
+// parseRetryAfter understands both forms of the Retry-After header: delay in seconds and an HTTP date.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs < 0 {
+			return 0
+		}
+
+		return time.Duration(secs) * time.Second
+	}
+
+	if when, err := http.ParseTime(value); err == nil {
+		if d := when.Sub(now); d > 0 {
+			return d
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,96 @@
+package gcd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIError_Is(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		status   int
+		sentinel error
+	}{
+		{"not-found", http.StatusNotFound, ErrNotFound},
+		{"gone", http.StatusGone, ErrNotFound},
+		{"rate-limited", http.StatusTooManyRequests, ErrRateLimited},
+		{"unauthorized", http.StatusUnauthorized, ErrUnauthorized},
+		{"forbidden", http.StatusForbidden, ErrUnauthorized},
+		{"server-error", http.StatusBadGateway, ErrServerError},
+		{"bad-request", http.StatusBadRequest, nil},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := error(&APIError{StatusCode: tt.status})
+
+			for _, sentinel := range []error{ErrNotFound, ErrRateLimited, ErrUnauthorized, ErrServerError} {
+				assert.Equal(t, sentinel == tt.sentinel, errors.Is(err, sentinel), "errors.Is(%v)", sentinel)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 11, 13, 12, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Duration(0), parseRetryAfter("", now))
+	assert.Equal(t, 120*time.Second, parseRetryAfter("120", now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("-1", now))
+	assert.Equal(t, 30*time.Second, parseRetryAfter(now.Add(30*time.Second).Format(http.TimeFormat), now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("garbage", now))
+}
+
+func TestAPI_StatusErrors(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/issue/1/":
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintln(w, "<html>not found</html>")
+		case "/api/series/2/":
+			w.Header().Set("Retry-After", "7")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	api := API{
+		Prefix: "http://" + server.Listener.Addr().String() + "/api/",
+	}
+
+	_, err := api.Issue(context.Background(), IssueReq{ID: 1})
+	require.ErrorIs(t, err, ErrNotFound)
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	assert.Equal(t, api.Prefix+"issue/1/", apiErr.URL)
+	assert.Contains(t, apiErr.Body, "not found")
+
+	_, err = api.SeriesInstance(context.Background(), 2)
+	require.ErrorIs(t, err, ErrRateLimited)
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, 7*time.Second, apiErr.RetryAfter)
+
+	_, err = api.Series(context.Background(), SeriesReq{Name: "Batman"})
+	require.ErrorIs(t, err, ErrServerError)
+}
@@ -0,0 +1,93 @@
+package gcd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+)
+
+// IndiciaPublisher is the legal entity named in the indicia of an issue, e.g. "DC Comics".
+type IndiciaPublisher struct {
+	APIURL             string          `json:"api_url"`
+	Name               string          `json:"name"`
+	Country            string          `json:"country"`
+	YearBegan          int             `json:"year_began"`
+	YearEnded          Optional[int]   `json:"year_ended"`
+	YearBeganUncertain bool            `json:"year_began_uncertain"`
+	YearEndedUncertain bool            `json:"year_ended_uncertain"`
+	IsSurrogate        bool            `json:"is_surrogate"`
+	URL                string          `json:"url"`
+	Notes              string          `json:"notes"`
+	Parent             Link[Publisher] `json:"parent"`
+	IssueCount         int             `json:"issue_count"`
+
+	payload
+}
+
+type IndiciaPublisherReq struct {
+	ID   int // indicia publisher ID should not be provided together with the indicia publisher Name
+	Name string
+
+	Format string // optional: "api" or "json"
+	Page   int
+}
+
+func (r IndiciaPublisherReq) URL(prefix string) (string, error) {
+	return resourceURL(prefix, "indicia_publisher", r.ID, r.Name, r.Format, r.Page)
+}
+
+type IndiciaPublisherResp struct {
+	Count    int                `json:"count"`
+	Next     string             `json:"next"`
+	Previous string             `json:"previous,omitempty"`
+	Results  []IndiciaPublisher `json:"results"`
+
+	payload
+}
+
+func (a API) IndiciaPublishersFromURL(ctx context.Context, url string) (IndiciaPublisherResp, error) {
+	return getAs[IndiciaPublisherResp](ctx, a, url)
+}
+
+func (a API) IndiciaPublishers(ctx context.Context, req IndiciaPublisherReq) (IndiciaPublisherResp, error) {
+	uu, err := req.URL(a.prefix())
+	if err != nil {
+		return IndiciaPublisherResp{}, fmt.Errorf("failed to construct URL: %w", err)
+	}
+
+	return a.IndiciaPublishersFromURL(ctx, uu)
+}
+
+// IndiciaPublishersAll returns an iterator over every indicia publisher matching req, following the next page links.
+func (a API) IndiciaPublishersAll(ctx context.Context, req IndiciaPublisherReq) iter.Seq2[IndiciaPublisher, error] {
+	uu, err := req.URL(a.prefix())
+	if err != nil {
+		return func(yield func(IndiciaPublisher, error) bool) {
+			yield(IndiciaPublisher{}, fmt.Errorf("failed to construct URL: %w", err))
+		}
+	}
+
+	return Paginate(ctx, uu, func(ctx context.Context, url string) ([]IndiciaPublisher, string, error) {
+		resp, err := a.IndiciaPublishersFromURL(ctx, url)
+
+		return resp.Results, resp.Next, err
+	}, a.Prefetch)
+}
+
+func (a API) IndiciaPublisherFromURL(ctx context.Context, url string) (IndiciaPublisher, error) {
+	return getAs[IndiciaPublisher](ctx, a, url)
+}
+
+func (a API) IndiciaPublisher(ctx context.Context, id int) (IndiciaPublisher, error) {
+	if id <= 0 {
+		return IndiciaPublisher{}, errors.New("invalid ID")
+	}
+
+	uu, err := IndiciaPublisherReq{ID: id}.URL(a.prefix())
+	if err != nil {
+		return IndiciaPublisher{}, fmt.Errorf("failed to construct URL: %w", err)
+	}
+
+	return a.IndiciaPublisherFromURL(ctx, uu)
+}
@@ -2,12 +2,9 @@ package gcd
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"strconv"
-	"strings"
 )
 
 type StorySet struct {
@@ -27,6 +24,8 @@ type StorySet struct {
 	Characters     string `json:"characters"`
 	Synopsis       string `json:"synopsis"`
 	Notes          string `json:"notes"`
+
+	payload
 }
 
 type IssueReq struct {
@@ -40,78 +39,50 @@ func (r IssueReq) URL(prefix string) (string, error) {
 		return "", errors.New("invalid ID")
 	}
 
-	url := prefix
-	if url[len(url)-1] == '/' {
-		url = url[:len(url)-1]
-	}
-
-	url += "/issue/" + strconv.Itoa(r.ID)
-	url += "/"
-
-	var params []string
-
-	if r.Format != "" {
-		params = append(params, "format="+r.Format)
-	}
-
-	if len(params) > 0 {
-		url += "?" + strings.Join(params, "&")
-	}
-
-	return url, nil
+	return buildURL(prefix, []string{"issue", strconv.Itoa(r.ID)}, requestQuery(r.Format, 0))
 }
 
 type IssueResp struct {
-	APIURL           string      `json:"api_url"`
-	SeriesName       string      `json:"series_name"`
-	Descriptor       string      `json:"descriptor"`
-	PublicationDate  string      `json:"publication_date"`
-	Price            string      `json:"price"`
-	PageCount        string      `json:"page_count"`
-	Editing          string      `json:"editing"`
-	Brand            string      `json:"brand"`
-	ISBN             string      `json:"isbn"`
-	Barcode          string      `json:"barcode"`
-	Rating           string      `json:"rating"`
-	OnSaleDate       string      `json:"on_sale_date"`
-	Notes            string      `json:"notes"`
-	VariantOf        interface{} `json:"variant_of"`
-	Series           string      `json:"series"`
-	Cover            string      `json:"cover"`
-	StorySet         []StorySet  `json:"story_set"`
-	IndiciaPublisher string      `json:"indicia_publisher"`
-	IndiciaFrequency string      `json:"indicia_frequency"`
+	APIURL           string               `json:"api_url"`
+	SeriesName       string               `json:"series_name"`
+	Descriptor       string               `json:"descriptor"`
+	PublicationDate  string               `json:"publication_date"`
+	Price            string               `json:"price"`
+	PageCount        string               `json:"page_count"`
+	Editing          string               `json:"editing"`
+	Brand            string               `json:"brand"`
+	ISBN             string               `json:"isbn"`
+	Barcode          string               `json:"barcode"`
+	Rating           string               `json:"rating"`
+	OnSaleDate       string               `json:"on_sale_date"`
+	Notes            string               `json:"notes"`
+	VariantOf        Link[IssueResp]      `json:"variant_of"`
+	Series           Link[SeriesInstance] `json:"series"`
+	Cover            string               `json:"cover"`
+	StorySet         []StorySet           `json:"story_set"`
+	IndiciaPublisher string               `json:"indicia_publisher"`
+	IndiciaFrequency string               `json:"indicia_frequency"`
+
+	payload
+}
+
+// IsVariant reports whether the issue is a variant of another issue, which VariantOf links to.
+func (r IssueResp) IsVariant() bool {
+	return !r.VariantOf.IsZero()
 }
 
 func (a API) IssueFromURL(ctx context.Context, url string) (IssueResp, error) {
 	var issueResp IssueResp
 
-	resp, err := a.req(ctx, url)
-	if err != nil {
-		return issueResp, fmt.Errorf("client.Do: %w", err)
-	}
-
-	defer resp.Body.Close()
-
-	data, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return issueResp, fmt.Errorf("io.ReadAll: %w", err)
-	}
-
-	if err := json.Unmarshal(data, &issueResp); err != nil {
-		return issueResp, fmt.Errorf("json.Unmarshal: %w", err)
+	if err := a.get(ctx, url, &issueResp); err != nil {
+		return issueResp, err
 	}
 
 	return issueResp, nil
 }
 
 func (a API) Issue(ctx context.Context, req IssueReq) (IssueResp, error) {
-	prefix := a.Prefix
-	if prefix == "" {
-		prefix = DefaultPrefix
-	}
-
-	uu, err := req.URL(prefix)
+	uu, err := req.URL(a.prefix())
 	if err != nil {
 		return IssueResp{}, fmt.Errorf("failed to construct URL: %w", err)
 	}
@@ -0,0 +1,140 @@
+package gcd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket limiter safe for concurrent use. A single limiter is meant to be shared
+// by every goroutine using the same API. It slows down automatically when the server answers with 429
+// and recovers gradually as requests succeed again.
+type RateLimiter struct {
+	mu sync.Mutex
+
+	baseRate float64 // configured requests per second
+	rate     float64 // current requests per second, lowered after 429 responses
+	burst    float64
+	tokens   float64
+	last     time.Time
+
+	stats LimiterStats
+}
+
+// LimiterStats reports how much the limiter has been delaying requests.
+type LimiterStats struct {
+	Requests  int64         // calls to Wait that were granted
+	Waits     int64         // calls to Wait that had to block
+	TotalWait time.Duration // accumulated time spent blocking
+	MaxWait   time.Duration // longest single wait
+	Throttled int64         // 429 responses observed
+	Rate      float64       // current requests per second
+}
+
+const (
+	limiterMinRateFactor  = 0.1  // the rate never drops below 10% of the configured one
+	limiterRecoveryFactor = 0.05 // each success recovers 5% of the configured rate
+)
+
+// NewRateLimiter returns a limiter allowing rps requests per second with bursts of up to burst requests.
+func NewRateLimiter(rps float64, burst int) (*RateLimiter, error) {
+	if rps <= 0 {
+		return nil, errors.New("rps must be greater than zero")
+	}
+
+	if burst < 1 {
+		return nil, errors.New("burst must be at least one")
+	}
+
+	return &RateLimiter{
+		baseRate: rps,
+		rate:     rps,
+		burst:    float64(burst),
+		tokens:   float64(burst),
+		last:     time.Now(),
+	}, nil
+}
+
+// refill must be called with mu held.
+func (l *RateLimiter) refill(now time.Time) {
+	if elapsed := now.Sub(l.last).Seconds(); elapsed > 0 {
+		l.tokens = min(l.burst, l.tokens+elapsed*l.rate)
+	}
+
+	l.last = now
+}
+
+// Wait blocks until a request is allowed or ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	_, err := l.wait(ctx)
+
+	return err
+}
+
+// wait is Wait, also returning how long it had to block.
+func (l *RateLimiter) wait(ctx context.Context) (time.Duration, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	l.mu.Lock()
+	l.refill(time.Now())
+	l.tokens--
+
+	var wait time.Duration
+	if l.tokens < 0 {
+		wait = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	l.mu.Unlock()
+
+	if wait > 0 {
+		if err := sleepCtx(ctx, wait); err != nil {
+			l.mu.Lock()
+			l.tokens++
+			l.mu.Unlock()
+
+			return 0, err
+		}
+	}
+
+	l.mu.Lock()
+	l.stats.Requests++
+	if wait > 0 {
+		l.stats.Waits++
+		l.stats.TotalWait += wait
+		l.stats.MaxWait = max(l.stats.MaxWait, wait)
+	}
+	l.mu.Unlock()
+
+	return wait, nil
+}
+
+// Stats returns a snapshot of the limiter counters.
+func (l *RateLimiter) Stats() LimiterStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	stats := l.stats
+	stats.Rate = l.rate
+
+	return stats
+}
+
+// observe adjusts the rate after a response with the given status code.
+func (l *RateLimiter) observe(statusCode int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.refill(time.Now())
+
+	switch {
+	case statusCode == http.StatusTooManyRequests:
+		l.stats.Throttled++
+		l.rate = max(l.rate/2, l.baseRate*limiterMinRateFactor)
+		l.tokens = min(l.tokens, 0)
+	case statusCode < http.StatusInternalServerError && l.rate < l.baseRate:
+		l.rate = min(l.baseRate, l.rate+l.baseRate*limiterRecoveryFactor)
+	}
+}
@@ -0,0 +1,125 @@
+package gcd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewRateLimiter(0, 1)
+	require.Error(t, err)
+
+	_, err = NewRateLimiter(1, 0)
+	require.Error(t, err)
+}
+
+func TestRateLimiter_Wait(t *testing.T) {
+	t.Parallel()
+
+	limiter, err := NewRateLimiter(100, 2)
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+
+	start := time.Now()
+
+	for range 6 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, limiter.Wait(context.Background()))
+		}()
+	}
+
+	wg.Wait()
+
+	// burst of 2 is free, the remaining 4 need 10ms each
+	assert.GreaterOrEqual(t, time.Since(start), 35*time.Millisecond)
+
+	stats := limiter.Stats()
+	assert.EqualValues(t, 6, stats.Requests)
+	assert.EqualValues(t, 4, stats.Waits)
+	assert.Greater(t, stats.MaxWait, time.Duration(0))
+	assert.InDelta(t, 100, stats.Rate, 0.001)
+}
+
+func TestRateLimiter_Wait_cancel(t *testing.T) {
+	t.Parallel()
+
+	limiter, err := NewRateLimiter(0.01, 1)
+	require.NoError(t, err)
+
+	require.NoError(t, limiter.Wait(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	t.Cleanup(cancel)
+
+	require.ErrorIs(t, limiter.Wait(ctx), context.DeadlineExceeded)
+	assert.EqualValues(t, 1, limiter.Stats().Requests)
+}
+
+func TestRateLimiter_observe(t *testing.T) {
+	t.Parallel()
+
+	limiter, err := NewRateLimiter(10, 1)
+	require.NoError(t, err)
+
+	limiter.observe(http.StatusTooManyRequests)
+	assert.InDelta(t, 5, limiter.Stats().Rate, 0.001)
+
+	for range 10 {
+		limiter.observe(http.StatusTooManyRequests)
+	}
+
+	assert.InDelta(t, 1, limiter.Stats().Rate, 0.001, "rate is floored")
+	assert.EqualValues(t, 11, limiter.Stats().Throttled)
+
+	for range 100 {
+		limiter.observe(http.StatusOK)
+	}
+
+	assert.InDelta(t, 10, limiter.Stats().Rate, 0.001, "rate recovers up to the configured one")
+}
+
+func TestAPI_limiter(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	t.Cleanup(server.Close)
+
+	anonymous, err := NewRateLimiter(1000, 10)
+	require.NoError(t, err)
+
+	session, err := NewRateLimiter(1000, 10)
+	require.NoError(t, err)
+
+	api := API{
+		Prefix:         "http://" + server.Listener.Addr().String() + "/api/",
+		Limiter:        anonymous,
+		SessionLimiter: session,
+	}
+
+	_, err = api.Issue(context.Background(), IssueReq{ID: 1})
+	require.ErrorIs(t, err, ErrRateLimited)
+	assert.EqualValues(t, 1, anonymous.Stats().Throttled)
+	assert.EqualValues(t, 0, session.Stats().Requests)
+
+	api.SessionID = "foobar123"
+
+	_, err = api.Issue(context.Background(), IssueReq{ID: 1})
+	require.ErrorIs(t, err, ErrRateLimited)
+	assert.EqualValues(t, 1, anonymous.Stats().Requests)
+	assert.EqualValues(t, 1, session.Stats().Requests)
+}
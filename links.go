@@ -0,0 +1,56 @@
+package gcd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Link is a gcd api URL pointing to a resource of type T, e.g. the series of an issue.
+// It decodes from the JSON string (or null) the api returns.
+type Link[T any] string
+
+// IsZero reports whether the link is empty, which is how the api represents a null reference.
+func (l Link[T]) IsZero() bool {
+	return l == ""
+}
+
+func (l Link[T]) String() string {
+	return string(l)
+}
+
+// ID extracts the numeric ID of the linked resource, e.g. 196803 for "https://www.comics.org/api/series/196803/".
+func (l Link[T]) ID() (int, error) {
+	if l.IsZero() {
+		return 0, errors.New("empty link")
+	}
+
+	u, err := url.Parse(string(l))
+	if err != nil {
+		return 0, fmt.Errorf("url.Parse: %w", err)
+	}
+
+	path := strings.TrimRight(u.Path, "/")
+	segment := path[strings.LastIndexByte(path, '/')+1:]
+
+	id, err := strconv.Atoi(segment)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("no resource ID in %q", string(l))
+	}
+
+	return id, nil
+}
+
+// Resolve fetches the linked resource.
+func (l Link[T]) Resolve(ctx context.Context, api API) (T, error) {
+	if l.IsZero() {
+		var zero T
+
+		return zero, errors.New("empty link")
+	}
+
+	return getAs[T](ctx, api, string(l))
+}
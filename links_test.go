@@ -0,0 +1,89 @@
+package gcd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLink_ID(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		link      Link[SeriesInstance]
+		expected  int
+		shouldErr bool
+	}{
+		{"series", "https://www.comics.org/api/series/196803/", 196803, false},
+		{"no-trailing-slash", "https://www.comics.org/api/series/196803", 196803, false},
+		{"query", "https://www.comics.org/api/series/196803/?format=json", 196803, false},
+		{"empty", "", 0, true},
+		{"no-id", "https://www.comics.org/api/series/", 0, true},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			id, err := tt.link.ID()
+			if tt.shouldErr {
+				require.Error(t, err, "expected error")
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, id)
+		})
+	}
+}
+
+func TestLink_Resolve(t *testing.T) {
+	t.Parallel()
+
+	var prefix string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/api/issue/2495111/":
+			fmt.Fprintf(w, `{"series_name": "Superman (2023 series)", "series": %q}`, prefix+"series/196803/")
+		case "/api/series/196803/":
+			fmt.Fprintf(w, `{"name": "Superman", "publisher": %q}`, prefix+"publisher/54/")
+		case "/api/publisher/54/":
+			fmt.Fprintln(w, dcPublisher)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	prefix = "http://" + server.Listener.Addr().String() + "/api/"
+	api := API{Prefix: prefix}
+
+	issue, err := api.Issue(context.Background(), IssueReq{ID: 2495111})
+	require.NoError(t, err, "api.Issue")
+
+	seriesID, err := issue.Series.ID()
+	require.NoError(t, err)
+	assert.Equal(t, 196803, seriesID)
+
+	series, err := issue.Series.Resolve(context.Background(), api)
+	require.NoError(t, err, "issue.Series.Resolve")
+	assert.Equal(t, "Superman", series.Name)
+
+	publisher, err := series.Publisher.Resolve(context.Background(), api)
+	require.NoError(t, err, "series.Publisher.Resolve")
+	assert.Equal(t, "DC", publisher.Name)
+
+	_, err = issue.VariantOf.Resolve(context.Background(), api)
+	require.Error(t, err, "null link")
+}
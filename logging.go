@@ -0,0 +1,118 @@
+package gcd
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"time"
+)
+
+// cacheOutcome describes how the cache took part in a request.
+type cacheOutcome string
+
+const (
+	cacheNone        cacheOutcome = ""
+	cacheHit         cacheOutcome = "hit"
+	cacheMiss        cacheOutcome = "miss"
+	cacheRevalidated cacheOutcome = "revalidated"
+)
+
+// callInfo collects what happened while serving one API call, for logging and instrumentation.
+type callInfo struct {
+	attempts    int // HTTP requests sent, including retries
+	status      int // status code of the last response
+	cache       cacheOutcome
+	limiterWait time.Duration // time spent waiting for the rate limiter
+	bytes       int64         // response body bytes read
+	endpoint    string        // normalized endpoint label, only set when metrics are collected
+}
+
+// countingReader counts the bytes read through it.
+type countingReader struct {
+	r io.Reader
+	n *int64
+}
+
+func (c countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	*c.n += int64(n)
+
+	return n, err
+}
+
+func (a API) logEnabled(ctx context.Context, level slog.Level) bool {
+	return a.Logger != nil && a.Logger.Enabled(ctx, level)
+}
+
+// logCall writes the record of a finished API call. Failed calls are logged at warn level.
+func (a API) logCall(ctx context.Context, url string, call *callInfo, duration time.Duration, err error) {
+	level := slog.LevelInfo
+	if err != nil {
+		level = slog.LevelWarn
+
+		var drift *SchemaDriftError
+		if errors.As(err, &drift) {
+			level = slog.LevelInfo
+		}
+	}
+
+	if !a.logEnabled(ctx, level) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", "GET"),
+		slog.String("url", url),
+		slog.Int("status", call.status),
+		slog.Duration("duration", duration),
+		slog.Int64("bytes", call.bytes),
+		slog.Int("attempts", call.attempts),
+		slog.Bool("authenticated", a.SessionID != ""),
+	}
+
+	if call.cache != cacheNone {
+		attrs = append(attrs, slog.String("cache", string(call.cache)))
+	}
+
+	if call.limiterWait > 0 {
+		attrs = append(attrs, slog.Duration("limiter_wait", call.limiterWait))
+	}
+
+	if err != nil {
+		attrs = append(attrs, slog.Any("error", err))
+	}
+
+	a.Logger.LogAttrs(ctx, level, "gcd request", attrs...)
+}
+
+// logPayload reports the decoded payload size and, outside of strict mode, any schema drift at debug level.
+func (a API) logPayload(ctx context.Context, url string, data []byte, v any) {
+	attrs := []slog.Attr{
+		slog.String("url", url),
+		slog.Int("payload_bytes", len(data)),
+	}
+
+	var drift *SchemaDriftError
+	if err := checkSchema(url, data, v); errors.As(err, &drift) {
+		attrs = append(attrs, slog.Any("unknown_fields", drift.Unknown), slog.Any("missing_fields", drift.Missing))
+		a.Logger.LogAttrs(ctx, slog.LevelDebug, "gcd schema drift", attrs...)
+
+		return
+	}
+
+	a.Logger.LogAttrs(ctx, slog.LevelDebug, "gcd payload", attrs...)
+}
+
+// LogValue keeps the session cookie out of logs when an API value is logged.
+func (a API) LogValue() slog.Value {
+	session := ""
+	if a.SessionID != "" {
+		session = "REDACTED"
+	}
+
+	return slog.GroupValue(
+		slog.String("prefix", a.prefix()),
+		slog.String("session_id", session),
+	)
+}
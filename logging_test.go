@@ -0,0 +1,129 @@
+package gcd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decodeLogRecords(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+
+	var records []map[string]any
+
+	dec := json.NewDecoder(buf)
+	for dec.More() {
+		var record map[string]any
+		require.NoError(t, dec.Decode(&record))
+
+		records = append(records, record)
+	}
+
+	return records
+}
+
+func TestAPI_Logger(t *testing.T) {
+	t.Parallel()
+
+	var calls int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"name": "Superman", "keywords": []}`)
+	}))
+	t.Cleanup(server.Close)
+
+	var buf bytes.Buffer
+
+	api := API{
+		Prefix:    "http://" + server.Listener.Addr().String() + "/api/",
+		SessionID: "foobar123",
+		Retry:     &RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond},
+		Cache:     NewMemoryCache(1),
+		CacheTTL:  time.Hour,
+		Logger:    slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}
+
+	_, err := api.SeriesInstance(context.Background(), 196803)
+	require.NoError(t, err)
+
+	_, err = api.SeriesInstance(context.Background(), 196803)
+	require.NoError(t, err)
+
+	assert.NotContains(t, buf.String(), "foobar123", "session cookie is never logged")
+
+	records := decodeLogRecords(t, &buf)
+	require.Len(t, records, 4)
+
+	assert.Equal(t, "gcd schema drift", records[0]["msg"])
+	assert.Equal(t, "DEBUG", records[0]["level"])
+	assert.Equal(t, []any{"keywords"}, records[0]["unknown_fields"])
+
+	first := records[1]
+	assert.Equal(t, "gcd request", first["msg"])
+	assert.Equal(t, "INFO", first["level"])
+	assert.Equal(t, "GET", first["method"])
+	assert.Equal(t, api.Prefix+"series/196803/", first["url"])
+	assert.EqualValues(t, http.StatusOK, first["status"])
+	assert.EqualValues(t, 2, first["attempts"])
+	assert.EqualValues(t, 36, first["bytes"])
+	assert.Equal(t, "miss", first["cache"])
+	assert.Equal(t, true, first["authenticated"])
+
+	second := records[3]
+	assert.Equal(t, "hit", second["cache"])
+	assert.EqualValues(t, 0, second["attempts"])
+}
+
+func TestAPI_Logger_error(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(server.Close)
+
+	var buf bytes.Buffer
+
+	api := API{
+		Prefix: "http://" + server.Listener.Addr().String() + "/api/",
+		Logger: slog.New(slog.NewJSONHandler(&buf, nil)),
+	}
+
+	_, err := api.Issue(context.Background(), IssueReq{ID: 1})
+	require.ErrorIs(t, err, ErrNotFound)
+
+	records := decodeLogRecords(t, &buf)
+	require.Len(t, records, 1)
+	assert.Equal(t, "WARN", records[0]["level"])
+	assert.EqualValues(t, http.StatusNotFound, records[0]["status"])
+	assert.Contains(t, records[0]["error"], "404")
+}
+
+func TestAPI_LogValue(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	logger.Info("client", "api", API{SessionID: "foobar123"})
+
+	assert.NotContains(t, buf.String(), "foobar123")
+	assert.Contains(t, buf.String(), "api.session_id=REDACTED")
+}
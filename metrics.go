@@ -0,0 +1,255 @@
+package gcd
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Metrics receives measurements of the client's activity. Endpoints are normalized URL paths such as
+// "/issue/{id}/", so their number stays small. Implementations must be safe for concurrent use.
+type Metrics interface {
+	ObserveRequest(endpoint string, status string, duration time.Duration) // one per HTTP attempt; status is "error" on network errors
+	ObserveRetry(endpoint string)
+	ObserveLimiterWait(endpoint string, wait time.Duration)
+	ObserveCache(endpoint string, outcome string) // outcome is "hit", "miss" or "revalidated"
+}
+
+// endpointLabel normalizes an api URL into a low cardinality label, e.g. "/series/name/{name}/year/{year}/"
+// for "https://www.comics.org/api/series/name/Batman/year/2000/?page=2".
+func endpointLabel(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "unknown"
+	}
+
+	segments := strings.Split(strings.Trim(u.EscapedPath(), "/"), "/")
+	if i := slices.Index(segments, "api"); i >= 0 {
+		segments = segments[i+1:]
+	}
+
+	for i := 1; i < len(segments); i++ {
+		switch segments[i-1] {
+		case "name":
+			segments[i] = "{name}"
+		case "year":
+			segments[i] = "{year}"
+		case "issue":
+			if i > 1 {
+				segments[i] = "{number}"
+
+				continue
+			}
+
+			fallthrough
+		default:
+			if _, err := strconv.Atoi(segments[i]); err == nil {
+				segments[i] = "{id}"
+			}
+		}
+	}
+
+	return "/" + strings.Join(segments, "/") + "/"
+}
+
+func (a API) observeAttempt(call *callInfo, resp *http.Response, err error, duration time.Duration) {
+	if a.Metrics == nil {
+		return
+	}
+
+	status := "error"
+	if err == nil {
+		status = strconv.Itoa(resp.StatusCode)
+	}
+
+	a.Metrics.ObserveRequest(call.endpoint, status, duration)
+}
+
+func (a API) observeCall(call *callInfo) {
+	if a.Metrics == nil {
+		return
+	}
+
+	for range call.attempts - 1 {
+		a.Metrics.ObserveRetry(call.endpoint)
+	}
+
+	if call.limiterWait > 0 {
+		a.Metrics.ObserveLimiterWait(call.endpoint, call.limiterWait)
+	}
+
+	if call.cache != cacheNone {
+		a.Metrics.ObserveCache(call.endpoint, string(call.cache))
+	}
+}
+
+// DefaultLatencyBuckets are the upper bounds, in seconds, of the request duration histogram.
+var DefaultLatencyBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// PrometheusMetrics is an in-memory Metrics implementation serving its values in the Prometheus text format.
+type PrometheusMetrics struct {
+	mu sync.Mutex
+
+	buckets []float64
+
+	requests    map[[2]string]float64 // endpoint, status
+	durations   map[string]*histogram // endpoint
+	retries     map[string]float64    // endpoint
+	waits       map[string]float64    // endpoint
+	waitSeconds map[string]float64    // endpoint
+	cache       map[[2]string]float64 // endpoint, outcome
+}
+
+type histogram struct {
+	counts []uint64 // per bucket, not cumulative
+	count  uint64
+	sum    float64
+}
+
+// NewPrometheusMetrics returns an empty collector. Without buckets, DefaultLatencyBuckets are used.
+func NewPrometheusMetrics(buckets ...float64) *PrometheusMetrics {
+	if len(buckets) == 0 {
+		buckets = DefaultLatencyBuckets
+	}
+
+	buckets = slices.Clone(buckets)
+	slices.Sort(buckets)
+
+	return &PrometheusMetrics{
+		buckets:     buckets,
+		requests:    make(map[[2]string]float64),
+		durations:   make(map[string]*histogram),
+		retries:     make(map[string]float64),
+		waits:       make(map[string]float64),
+		waitSeconds: make(map[string]float64),
+		cache:       make(map[[2]string]float64),
+	}
+}
+
+func (m *PrometheusMetrics) ObserveRequest(endpoint string, status string, duration time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests[[2]string{endpoint, status}]++
+
+	h, ok := m.durations[endpoint]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(m.buckets))}
+		m.durations[endpoint] = h
+	}
+
+	seconds := duration.Seconds()
+	if i, _ := slices.BinarySearch(m.buckets, seconds); i < len(m.buckets) {
+		h.counts[i]++
+	}
+
+	h.count++
+	h.sum += seconds
+}
+
+func (m *PrometheusMetrics) ObserveRetry(endpoint string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.retries[endpoint]++
+}
+
+func (m *PrometheusMetrics) ObserveLimiterWait(endpoint string, wait time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.waits[endpoint]++
+	m.waitSeconds[endpoint] += wait.Seconds()
+}
+
+func (m *PrometheusMetrics) ObserveCache(endpoint string, outcome string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.cache[[2]string{endpoint, outcome}]++
+}
+
+// ServeHTTP renders the metrics in the Prometheus text exposition format.
+func (m *PrometheusMetrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = m.WriteText(w)
+}
+
+// WriteText writes the metrics in the Prometheus text exposition format.
+func (m *PrometheusMetrics) WriteText(w io.Writer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var b strings.Builder
+
+	writeHeader := func(name, kind, help string) {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+	}
+
+	writeHeader("gcd_requests_total", "counter", "HTTP requests sent to the gcd api.")
+	for _, key := range sortedKeys(m.requests) {
+		fmt.Fprintf(&b, "gcd_requests_total{endpoint=%q,status=%q} %s\n", key[0], key[1], formatFloat(m.requests[key]))
+	}
+
+	writeHeader("gcd_request_duration_seconds", "histogram", "Time until the response headers of the gcd api arrived.")
+	for _, endpoint := range sortedKeys(m.durations) {
+		h := m.durations[endpoint]
+
+		var cumulative uint64
+		for i, upper := range m.buckets {
+			cumulative += h.counts[i]
+			fmt.Fprintf(&b, "gcd_request_duration_seconds_bucket{endpoint=%q,le=%q} %d\n", endpoint, formatFloat(upper), cumulative)
+		}
+
+		fmt.Fprintf(&b, "gcd_request_duration_seconds_bucket{endpoint=%q,le=\"+Inf\"} %d\n", endpoint, h.count)
+		fmt.Fprintf(&b, "gcd_request_duration_seconds_sum{endpoint=%q} %s\n", endpoint, formatFloat(h.sum))
+		fmt.Fprintf(&b, "gcd_request_duration_seconds_count{endpoint=%q} %d\n", endpoint, h.count)
+	}
+
+	writeHeader("gcd_retries_total", "counter", "Requests retried after a failed attempt.")
+	for _, endpoint := range sortedKeys(m.retries) {
+		fmt.Fprintf(&b, "gcd_retries_total{endpoint=%q} %s\n", endpoint, formatFloat(m.retries[endpoint]))
+	}
+
+	writeHeader("gcd_rate_limit_waits_total", "counter", "Calls delayed by the client side rate limiter.")
+	for _, endpoint := range sortedKeys(m.waits) {
+		fmt.Fprintf(&b, "gcd_rate_limit_waits_total{endpoint=%q} %s\n", endpoint, formatFloat(m.waits[endpoint]))
+	}
+
+	writeHeader("gcd_rate_limit_wait_seconds_total", "counter", "Time spent waiting for the client side rate limiter.")
+	for _, endpoint := range sortedKeys(m.waitSeconds) {
+		fmt.Fprintf(&b, "gcd_rate_limit_wait_seconds_total{endpoint=%q} %s\n", endpoint, formatFloat(m.waitSeconds[endpoint]))
+	}
+
+	writeHeader("gcd_cache_requests_total", "counter", "Calls served with the response cache, by outcome.")
+	for _, key := range sortedKeys(m.cache) {
+		fmt.Fprintf(&b, "gcd_cache_requests_total{endpoint=%q,outcome=%q} %s\n", key[0], key[1], formatFloat(m.cache[key]))
+	}
+
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+func sortedKeys[K interface{ string | [2]string }, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	slices.SortFunc(keys, func(a, b K) int {
+		return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+	})
+
+	return keys
+}
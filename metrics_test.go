@@ -0,0 +1,123 @@
+package gcd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndpointLabel(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		url      string
+		expected string
+	}{
+		{"https://www.comics.org/api/issue/2495111/", "/issue/{id}/"},
+		{"https://www.comics.org/api/series/196803/?format=json", "/series/{id}/"},
+		{"https://www.comics.org/api/series/name/Batman/issue/12/year/2000/", "/series/name/{name}/issue/{number}/year/{year}/"},
+		{"https://www.comics.org/api/series/?page=3", "/series/"},
+		{"https://www.comics.org/api/publisher/name/DC/", "/publisher/name/{name}/"},
+		{"https://www.comics.org/api/series/name/Spider-Man%2FDeadpool/year/2016/", "/series/name/{name}/year/{year}/"},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.expected, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, endpointLabel(tt.url))
+		})
+	}
+
+	issueURL, err := IssueReq{ID: 42}.URL(TestPrefix)
+	require.NoError(t, err)
+	assert.Equal(t, "/issue/{id}/", endpointLabel(issueURL))
+
+	seriesURL, err := SeriesReq{Name: "Superman", Year: 2023, Page: 2}.URL(TestPrefix)
+	require.NoError(t, err)
+	assert.Equal(t, "/series/name/{name}/year/{year}/", endpointLabel(seriesURL))
+}
+
+func TestPrometheusMetrics(t *testing.T) {
+	t.Parallel()
+
+	var calls int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "max-age=60")
+		fmt.Fprintln(w, superman2023_1Issue)
+	}))
+	t.Cleanup(server.Close)
+
+	metrics := NewPrometheusMetrics(0.5, 1)
+
+	limiter, err := NewRateLimiter(10, 1) // the retry has to wait for a token
+	require.NoError(t, err)
+
+	api := API{
+		Prefix:  "http://" + server.Listener.Addr().String() + "/api/",
+		Retry:   &RetryPolicy{MaxAttempts: 2, BaseDelay: 0},
+		Limiter: limiter,
+		Cache:   NewMemoryCache(10),
+		Metrics: metrics,
+	}
+
+	for range 2 {
+		_, err := api.Issue(context.Background(), IssueReq{ID: 2495111})
+		require.NoError(t, err)
+	}
+
+	rec := httptest.NewRecorder()
+	metrics.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	body, err := io.ReadAll(rec.Body)
+	require.NoError(t, err)
+
+	text := string(body)
+	assert.Contains(t, rec.Header().Get("Content-Type"), "text/plain")
+	assert.Contains(t, text, "# TYPE gcd_requests_total counter\n")
+	assert.Contains(t, text, `gcd_requests_total{endpoint="/issue/{id}/",status="200"} 1`+"\n")
+	assert.Contains(t, text, `gcd_requests_total{endpoint="/issue/{id}/",status="503"} 1`+"\n")
+	assert.Contains(t, text, `gcd_request_duration_seconds_bucket{endpoint="/issue/{id}/",le="0.5"} 2`+"\n")
+	assert.Contains(t, text, `gcd_request_duration_seconds_bucket{endpoint="/issue/{id}/",le="+Inf"} 2`+"\n")
+	assert.Contains(t, text, `gcd_request_duration_seconds_count{endpoint="/issue/{id}/"} 2`+"\n")
+	assert.Contains(t, text, `gcd_retries_total{endpoint="/issue/{id}/"} 1`+"\n")
+	assert.Contains(t, text, `gcd_rate_limit_waits_total{endpoint="/issue/{id}/"} 1`+"\n")
+	assert.Contains(t, text, `gcd_cache_requests_total{endpoint="/issue/{id}/",outcome="hit"} 1`+"\n")
+	assert.Contains(t, text, `gcd_cache_requests_total{endpoint="/issue/{id}/",outcome="miss"} 1`+"\n")
+}
+
+func TestPrometheusMetrics_buckets(t *testing.T) {
+	t.Parallel()
+
+	metrics := NewPrometheusMetrics(1, 0.1)
+	metrics.ObserveRequest("/issue/{id}/", "200", 50*time.Millisecond)
+	metrics.ObserveRequest("/issue/{id}/", "200", 500*time.Millisecond)
+	metrics.ObserveRequest("/issue/{id}/", "error", 5*time.Second)
+
+	rec := httptest.NewRecorder()
+	metrics.ServeHTTP(rec, nil)
+
+	text := rec.Body.String()
+	assert.Contains(t, text, `gcd_request_duration_seconds_bucket{endpoint="/issue/{id}/",le="0.1"} 1`+"\n")
+	assert.Contains(t, text, `gcd_request_duration_seconds_bucket{endpoint="/issue/{id}/",le="1"} 2`+"\n")
+	assert.Contains(t, text, `gcd_request_duration_seconds_bucket{endpoint="/issue/{id}/",le="+Inf"} 3`+"\n")
+	assert.Contains(t, text, `gcd_request_duration_seconds_sum{endpoint="/issue/{id}/"} 5.55`+"\n")
+	assert.Contains(t, text, `gcd_requests_total{endpoint="/issue/{id}/",status="error"} 1`+"\n")
+}
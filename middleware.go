@@ -0,0 +1,84 @@
+package gcd
+
+import (
+	"log/slog"
+	"net/http"
+	"slices"
+	"time"
+)
+
+// Middleware wraps an HTTPDoer, e.g. to log or modify every request the API sends.
+type Middleware func(HTTPDoer) HTTPDoer
+
+// HTTPDoerFunc adapts a function to HTTPDoer.
+type HTTPDoerFunc func(*http.Request) (*http.Response, error)
+
+func (f HTTPDoerFunc) Do(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+// Use appends middlewares to the chain. The first middleware added is the outermost one.
+func (a *API) Use(mw ...Middleware) {
+	a.Middlewares = slices.Concat(a.Middlewares, mw)
+}
+
+// doer returns the client wrapped by the middlewares.
+func (a API) doer() HTTPDoer {
+	return a.chain(a.client())
+}
+
+// chain wraps doer with the middlewares.
+func (a API) chain(doer HTTPDoer) HTTPDoer {
+	for i := len(a.Middlewares) - 1; i >= 0; i-- {
+		doer = a.Middlewares[i](doer)
+	}
+
+	return doer
+}
+
+// LoggingMiddleware logs one record per HTTP request at debug level, or at warn level when it fails.
+func LoggingMiddleware(logger *slog.Logger) Middleware {
+	return TimingMiddleware(func(req *http.Request, resp *http.Response, err error, duration time.Duration) {
+		attrs := []slog.Attr{
+			slog.String("method", req.Method),
+			slog.String("url", req.URL.String()),
+			slog.Duration("duration", duration),
+		}
+
+		if err != nil {
+			logger.LogAttrs(req.Context(), slog.LevelWarn, "gcd request failed", append(attrs, slog.Any("error", err))...)
+
+			return
+		}
+
+		logger.LogAttrs(req.Context(), slog.LevelDebug, "gcd request", append(attrs, slog.Int("status", resp.StatusCode))...)
+	})
+}
+
+// HeaderMiddleware sets the given headers on every request, replacing existing values.
+func HeaderMiddleware(header http.Header) Middleware {
+	return func(next HTTPDoer) HTTPDoer {
+		return HTTPDoerFunc(func(req *http.Request) (*http.Response, error) {
+			req = req.Clone(req.Context())
+
+			for key, values := range header {
+				req.Header[http.CanonicalHeaderKey(key)] = slices.Clone(values)
+			}
+
+			return next.Do(req)
+		})
+	}
+}
+
+// TimingMiddleware calls observe after every request with how long it took until the response headers arrived.
+func TimingMiddleware(observe func(req *http.Request, resp *http.Response, err error, duration time.Duration)) Middleware {
+	return func(next HTTPDoer) HTTPDoer {
+		return HTTPDoerFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.Do(req)
+			observe(req, resp, err, time.Since(start))
+
+			return resp, err
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package gcd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPI_Use(t *testing.T) {
+	t.Parallel()
+
+	var order []string
+
+	tag := func(name string) Middleware {
+		return func(next HTTPDoer) HTTPDoer {
+			return HTTPDoerFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+
+				return next.Do(req)
+			})
+		}
+	}
+
+	var headers http.Header
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header.Clone()
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintln(w, supermanSeriesInstance)
+	}))
+	t.Cleanup(server.Close)
+
+	var (
+		timed    int
+		duration time.Duration
+	)
+
+	api := API{
+		Prefix: "http://" + server.Listener.Addr().String() + "/api/",
+	}
+	api.Use(tag("first"), tag("second"))
+	api.Use(
+		HeaderMiddleware(http.Header{"x-contact": {"ops@example.org"}}),
+		TimingMiddleware(func(_ *http.Request, resp *http.Response, err error, d time.Duration) {
+			timed++
+			duration = d
+
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		}),
+	)
+
+	_, err := api.SeriesInstance(context.Background(), 196803)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"first", "second"}, order)
+	assert.Equal(t, "ops@example.org", headers.Get("X-Contact"))
+	assert.Equal(t, 1, timed)
+	assert.Greater(t, duration, time.Duration(0))
+}
+
+func TestLoggingMiddleware(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(server.Close)
+
+	var buf bytes.Buffer
+
+	api := API{
+		Prefix:      "http://" + server.Listener.Addr().String() + "/api/",
+		Middlewares: []Middleware{LoggingMiddleware(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))},
+	}
+
+	_, err := api.Issue(context.Background(), IssueReq{ID: 1})
+	require.ErrorIs(t, err, ErrNotFound)
+
+	assert.Contains(t, buf.String(), "msg=\"gcd request\"")
+	assert.Contains(t, buf.String(), "status=404")
+	assert.Contains(t, buf.String(), "/api/issue/1/")
+}
@@ -0,0 +1,47 @@
+package gcd
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Optional holds a value the api may return as null, such as the year an ongoing series ended.
+type Optional[T any] struct {
+	Value T
+	Valid bool // false when the value was null or missing
+}
+
+// Some returns a valid Optional holding v.
+func Some[T any](v T) Optional[T] {
+	return Optional[T]{Value: v, Valid: true}
+}
+
+// Get returns the value and whether it is set.
+func (o Optional[T]) Get() (T, bool) {
+	return o.Value, o.Valid
+}
+
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if !o.Valid {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(o.Value)
+}
+
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*o = Optional[T]{}
+
+		return nil
+	}
+
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	*o = Some(v)
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package gcd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptional_JSON(t *testing.T) {
+	t.Parallel()
+
+	var v struct {
+		Null    Optional[int] `json:"null"`
+		Set     Optional[int] `json:"set"`
+		Missing Optional[int] `json:"missing"`
+	}
+
+	require.NoError(t, json.Unmarshal([]byte(`{"null": null, "set": 2023}`), &v))
+
+	assert.Equal(t, Optional[int]{}, v.Null)
+	assert.Equal(t, Some(2023), v.Set)
+	assert.Equal(t, Optional[int]{}, v.Missing)
+
+	year, ok := v.Set.Get()
+	assert.True(t, ok)
+	assert.Equal(t, 2023, year)
+
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"null": null, "set": 2023, "missing": null}`, string(data))
+
+	require.Error(t, json.Unmarshal([]byte(`{"set": "2023"}`), &v))
+}
+
+func TestSeriesInstance_IsOngoing(t *testing.T) {
+	t.Parallel()
+
+	var resp SeriesResp
+	require.NoError(t, json.Unmarshal([]byte(supermanSeriesList), &resp))
+	require.Len(t, resp.Results, 2)
+
+	assert.False(t, resp.Results[0].IsOngoing(), "limited series")
+	assert.Equal(t, Some(2023), resp.Results[0].YearEnded)
+	assert.True(t, resp.Results[1].IsOngoing(), "ongoing series")
+}
+
+func TestIssueResp_IsVariant(t *testing.T) {
+	t.Parallel()
+
+	var issue IssueResp
+	require.NoError(t, json.Unmarshal([]byte(superman2023_1Issue), &issue))
+	assert.False(t, issue.IsVariant())
+
+	require.NoError(t, json.Unmarshal([]byte(`{"variant_of": "https://www.comics.org/api/issue/2495111/"}`), &issue))
+	assert.True(t, issue.IsVariant())
+
+	id, err := issue.VariantOf.ID()
+	require.NoError(t, err)
+	assert.Equal(t, 2495111, id)
+}
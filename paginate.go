@@ -0,0 +1,102 @@
+package gcd
+
+import (
+	"context"
+	"fmt"
+	"iter"
+)
+
+// PageFetcher fetches the page at url, returning its results and the URL of the next page ("" on the last page).
+type PageFetcher[T any] func(ctx context.Context, url string) ([]T, string, error)
+
+type pageResult[T any] struct {
+	results []T
+	next    string
+	err     error
+}
+
+// Paginate walks a paginated listing starting at url and following the next links returned by fetch.
+// The iteration stops after the first error, which is yielded with the zero value of T, or when ctx is done.
+// When prefetch is true, the next page is requested while the current one is being consumed.
+func Paginate[T any](ctx context.Context, url string, fetch PageFetcher[T], prefetch bool) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		start := func(url string) <-chan pageResult[T] {
+			ch := make(chan pageResult[T], 1)
+
+			go func() {
+				results, next, err := fetch(ctx, url)
+				ch <- pageResult[T]{results: results, next: next, err: err}
+			}()
+
+			return ch
+		}
+
+		var pending <-chan pageResult[T]
+
+		for url != "" {
+			if pending == nil {
+				pending = start(url)
+			}
+
+			var page pageResult[T]
+
+			select {
+			case <-ctx.Done():
+				var zero T
+				yield(zero, ctx.Err())
+
+				return
+			case page = <-pending:
+			}
+
+			if page.err != nil {
+				var zero T
+				yield(zero, page.err)
+
+				return
+			}
+
+			pending = nil
+			if page.next == url {
+				page.next = ""
+			}
+
+			url = page.next
+			if prefetch && url != "" {
+				pending = start(url)
+			}
+
+			for _, result := range page.results {
+				if err := ctx.Err(); err != nil {
+					var zero T
+					yield(zero, err)
+
+					return
+				}
+
+				if !yield(result, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// SeriesAll returns an iterator over every series matching req, following the next page links.
+func (a API) SeriesAll(ctx context.Context, req SeriesReq) iter.Seq2[SeriesInstance, error] {
+	uu, err := req.URL(a.prefix())
+	if err != nil {
+		return func(yield func(SeriesInstance, error) bool) {
+			yield(SeriesInstance{}, fmt.Errorf("failed to construct URL: %w", err))
+		}
+	}
+
+	return Paginate(ctx, uu, func(ctx context.Context, url string) ([]SeriesInstance, string, error) {
+		resp, err := a.SeriesFromURL(ctx, url)
+
+		return resp.Results, resp.Next, err
+	}, a.Prefetch)
+}
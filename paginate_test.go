@@ -0,0 +1,180 @@
+package gcd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaginate(t *testing.T) {
+	t.Parallel()
+
+	pages := map[string]struct {
+		results []int
+		next    string
+	}{
+		"p1": {[]int{1, 2}, "p2"},
+		"p2": {[]int{3}, "p3"},
+		"p3": {[]int{4, 5}, ""},
+	}
+
+	for _, prefetch := range []bool{false, true} {
+		t.Run("prefetch="+strconv.FormatBool(prefetch), func(t *testing.T) {
+			t.Parallel()
+
+			var fetched atomic.Int32
+
+			fetch := func(_ context.Context, url string) ([]int, string, error) {
+				fetched.Add(1)
+
+				page := pages[url]
+
+				return page.results, page.next, nil
+			}
+
+			var got []int
+
+			for v, err := range Paginate(context.Background(), "p1", fetch, prefetch) {
+				require.NoError(t, err)
+
+				got = append(got, v)
+			}
+
+			assert.Equal(t, []int{1, 2, 3, 4, 5}, got)
+			assert.EqualValues(t, 3, fetched.Load())
+		})
+	}
+}
+
+func TestPaginate_break(t *testing.T) {
+	t.Parallel()
+
+	var fetched atomic.Int32
+
+	fetch := func(_ context.Context, url string) ([]int, string, error) {
+		fetched.Add(1)
+
+		return []int{1, 2}, url + "+", nil
+	}
+
+	for v, err := range Paginate(context.Background(), "p", fetch, false) {
+		require.NoError(t, err)
+
+		if v == 2 {
+			break
+		}
+	}
+
+	assert.EqualValues(t, 1, fetched.Load(), "no page fetched after break")
+}
+
+func TestPaginate_error(t *testing.T) {
+	t.Parallel()
+
+	errBoom := errors.New("boom")
+
+	fetch := func(_ context.Context, url string) ([]int, string, error) {
+		if url == "p2" {
+			return nil, "", errBoom
+		}
+
+		return []int{1}, "p2", nil
+	}
+
+	var (
+		got  []int
+		errs []error
+	)
+
+	for v, err := range Paginate(context.Background(), "p1", fetch, true) {
+		if err != nil {
+			errs = append(errs, err)
+
+			continue
+		}
+
+		got = append(got, v)
+	}
+
+	assert.Equal(t, []int{1}, got)
+	require.Len(t, errs, 1)
+	require.ErrorIs(t, errs[0], errBoom)
+}
+
+func TestPaginate_cancel(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	fetch := func(_ context.Context, url string) ([]int, string, error) {
+		return []int{1, 2, 3}, url + "+", nil
+	}
+
+	var (
+		got     []int
+		lastErr error
+	)
+
+	for v, err := range Paginate(ctx, "p", fetch, true) {
+		if err != nil {
+			lastErr = err
+
+			break
+		}
+
+		got = append(got, v)
+		if v == 2 {
+			cancel()
+		}
+	}
+
+	assert.Equal(t, []int{1, 2}, got)
+	require.ErrorIs(t, lastErr, context.Canceled)
+}
+
+func TestAPI_SeriesAll(t *testing.T) {
+	t.Parallel()
+
+	var prefix string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Query().Get("page") {
+		case "":
+			fmt.Fprintf(w, `{"count": 3, "next": %q, "results": [{"name": "Superman"}, {"name": "Batman"}]}`,
+				prefix+"series/name/man/?page=2")
+		case "2":
+			fmt.Fprintln(w, `{"count": 3, "next": null, "results": [{"name": "Aquaman"}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	prefix = "http://" + server.Listener.Addr().String() + "/api/"
+
+	api := API{
+		Prefix:   prefix,
+		Prefetch: true,
+	}
+
+	var names []string
+
+	for series, err := range api.SeriesAll(context.Background(), SeriesReq{Name: "man"}) {
+		require.NoError(t, err)
+
+		names = append(names, series.Name)
+	}
+
+	assert.Equal(t, []string{"Superman", "Batman", "Aquaman"}, names)
+}
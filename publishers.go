@@ -0,0 +1,93 @@
+package gcd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+)
+
+type Publisher struct {
+	APIURL                string        `json:"api_url"`
+	Name                  string        `json:"name"`
+	Country               string        `json:"country"`
+	YearBegan             int           `json:"year_began"`
+	YearEnded             Optional[int] `json:"year_ended"`
+	YearBeganUncertain    bool          `json:"year_began_uncertain"`
+	YearEndedUncertain    bool          `json:"year_ended_uncertain"`
+	URL                   string        `json:"url"`
+	Notes                 string        `json:"notes"`
+	BrandCount            int           `json:"brand_count"`
+	IndiciaPublisherCount int           `json:"indicia_publisher_count"`
+	SeriesCount           int           `json:"series_count"`
+	IssueCount            int           `json:"issue_count"`
+
+	payload
+}
+
+type PublisherReq struct {
+	ID   int // publisher ID should not be provided together with the publisher Name
+	Name string
+
+	Format string // optional: "api" or "json"
+	Page   int
+}
+
+func (r PublisherReq) URL(prefix string) (string, error) {
+	return resourceURL(prefix, "publisher", r.ID, r.Name, r.Format, r.Page)
+}
+
+type PublisherResp struct {
+	Count    int         `json:"count"`
+	Next     string      `json:"next"`
+	Previous string      `json:"previous,omitempty"`
+	Results  []Publisher `json:"results"`
+
+	payload
+}
+
+func (a API) PublishersFromURL(ctx context.Context, url string) (PublisherResp, error) {
+	return getAs[PublisherResp](ctx, a, url)
+}
+
+func (a API) Publishers(ctx context.Context, req PublisherReq) (PublisherResp, error) {
+	uu, err := req.URL(a.prefix())
+	if err != nil {
+		return PublisherResp{}, fmt.Errorf("failed to construct URL: %w", err)
+	}
+
+	return a.PublishersFromURL(ctx, uu)
+}
+
+// PublishersAll returns an iterator over every publisher matching req, following the next page links.
+func (a API) PublishersAll(ctx context.Context, req PublisherReq) iter.Seq2[Publisher, error] {
+	uu, err := req.URL(a.prefix())
+	if err != nil {
+		return func(yield func(Publisher, error) bool) {
+			yield(Publisher{}, fmt.Errorf("failed to construct URL: %w", err))
+		}
+	}
+
+	return Paginate(ctx, uu, func(ctx context.Context, url string) ([]Publisher, string, error) {
+		resp, err := a.PublishersFromURL(ctx, url)
+
+		return resp.Results, resp.Next, err
+	}, a.Prefetch)
+}
+
+func (a API) PublisherFromURL(ctx context.Context, url string) (Publisher, error) {
+	return getAs[Publisher](ctx, a, url)
+}
+
+func (a API) Publisher(ctx context.Context, id int) (Publisher, error) {
+	if id <= 0 {
+		return Publisher{}, errors.New("invalid ID")
+	}
+
+	uu, err := PublisherReq{ID: id}.URL(a.prefix())
+	if err != nil {
+		return Publisher{}, fmt.Errorf("failed to construct URL: %w", err)
+	}
+
+	return a.PublisherFromURL(ctx, uu)
+}
@@ -0,0 +1,157 @@
+package gcd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const dcPublisher = `{
+	"api_url": "https://www.comics.org/api/publisher/54/",
+	"name": "DC",
+	"country": "us",
+	"year_began": 1935,
+	"year_ended": null,
+	"year_began_uncertain": false,
+	"year_ended_uncertain": false,
+	"url": "http://www.dccomics.com/",
+	"notes": "",
+	"brand_count": 37,
+	"indicia_publisher_count": 27,
+	"series_count": 8772,
+	"issue_count": 56003
+}`
+
+func TestPublisherReq_URL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		req       PublisherReq
+		expected  string
+		shouldErr bool
+	}{
+		{
+			"list",
+			PublisherReq{},
+			"https://example.org/api/publisher/",
+			false,
+		},
+		{
+			"id",
+			PublisherReq{ID: 54},
+			"https://example.org/api/publisher/54/",
+			false,
+		},
+		{
+			"name-page",
+			PublisherReq{Name: "Marvel", Page: 2},
+			"https://example.org/api/publisher/name/Marvel/?page=2",
+			false,
+		},
+		{
+			"id-and-name",
+			PublisherReq{ID: 54, Name: "DC"},
+			"",
+			true,
+		},
+		{
+			"negative-id",
+			PublisherReq{ID: -1},
+			"",
+			true,
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			resp, err := tt.req.URL(TestPrefix)
+			if tt.shouldErr {
+				require.Error(t, err, "expected error")
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, resp)
+		})
+	}
+}
+
+func TestAPI_Publisher(t *testing.T) {
+	t.Parallel()
+
+	responses := map[string]string{
+		"/api/publisher/54/":      dcPublisher,
+		"/api/publisher/name/DC/": `{"count": 1, "next": null, "previous": null, "results": [` + dcPublisher + `]}`,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		respData, ok := responses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintln(w, respData)
+	}))
+	t.Cleanup(server.Close)
+
+	api := API{
+		Prefix: "http://" + server.Listener.Addr().String() + "/api/",
+	}
+
+	t.Run("instance", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := api.Publisher(context.Background(), 54)
+		require.NoError(t, err, "api.Publisher")
+
+		assert.Equal(t, "DC", resp.Name)
+		assert.Equal(t, "us", resp.Country)
+		assert.Equal(t, 1935, resp.YearBegan)
+		assert.Equal(t, 8772, resp.SeriesCount)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := api.Publishers(context.Background(), PublisherReq{Name: "DC"})
+		require.NoError(t, err, "api.Publishers")
+
+		assert.Equal(t, 1, resp.Count)
+		require.Len(t, resp.Results, 1)
+		assert.Equal(t, "DC", resp.Results[0].Name)
+	})
+
+	t.Run("all", func(t *testing.T) {
+		t.Parallel()
+
+		var names []string
+
+		for publisher, err := range api.PublishersAll(context.Background(), PublisherReq{Name: "DC"}) {
+			require.NoError(t, err)
+
+			names = append(names, publisher.Name)
+		}
+
+		assert.Equal(t, []string{"DC"}, names)
+	})
+
+	t.Run("not-found", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := api.Publisher(context.Background(), 1)
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+}
@@ -0,0 +1,319 @@
+package gcd
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// payload keeps the JSON a response was decoded from. It is embedded in every response type.
+type payload struct {
+	raw   json.RawMessage
+	extra map[string]json.RawMessage
+}
+
+// Raw returns the exact JSON the value was decoded from.
+func (p payload) Raw() json.RawMessage {
+	return p.raw
+}
+
+// Extra returns the fields of the payload that have no matching struct field, keyed by JSON name.
+func (p payload) Extra() map[string]json.RawMessage {
+	return p.extra
+}
+
+func (p payload) preservesPayload() {}
+
+// payloadPreserver is implemented by the response types embedding payload.
+type payloadPreserver interface {
+	preservesPayload()
+}
+
+var knownFieldsCache sync.Map // reflect.Type -> map[string]bool
+
+// jsonFieldNames returns the JSON names of the exported fields of the struct type t.
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	if cached, ok := knownFieldsCache.Load(t); ok {
+		return cached.(map[string]bool)
+	}
+
+	names := make(map[string]bool)
+
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = field.Name
+		}
+
+		names[name] = true
+	}
+
+	knownFieldsCache.Store(t, names)
+
+	return names
+}
+
+// ownedBuffers holds the buffers that decoded values may borrow from: the copy made by the outermost
+// UnmarshalJSON, or a response body read by the API. Nested values are handed slices of the same buffer by
+// encoding/json, which share its end.
+var ownedBuffers sync.Map // *byte, the last byte of the buffer's capacity -> struct{}
+
+func bufferEnd(data []byte) *byte {
+	if cap(data) == 0 {
+		return nil
+	}
+
+	return &data[:cap(data)][cap(data)-1]
+}
+
+func owned(data []byte) bool {
+	end := bufferEnd(data)
+	if end == nil {
+		return false
+	}
+
+	_, ok := ownedBuffers.Load(end)
+
+	return ok
+}
+
+// unmarshalOwned decodes data into v, letting the decoded values borrow from data instead of copying it.
+// data must not be modified afterwards.
+func unmarshalOwned(data []byte, v any) error {
+	defer own(data)()
+
+	return json.Unmarshal(data, v)
+}
+
+// own records data in ownedBuffers until the returned function is called.
+func own(data []byte) func() {
+	end := bufferEnd(data)
+	if end == nil {
+		return func() {}
+	}
+
+	if _, loaded := ownedBuffers.LoadOrStore(end, struct{}{}); loaded {
+		return func() {}
+	}
+
+	return func() { ownedBuffers.Delete(end) }
+}
+
+// unmarshalPreserving decodes data into v, a pointer to a plain copy of the response type without its
+// UnmarshalJSON method, and records the raw payload and unknown fields into p. The outermost value copies data
+// once, as json.Unmarshaler requires, and nested values borrow from that copy.
+func unmarshalPreserving(data []byte, v any, p *payload) error {
+	if !owned(data) {
+		data = bytes.Clone(data)
+		defer own(data)()
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+
+	*p = payload{}
+
+	trimmed := bytes.TrimSpace(data)
+	if bytes.Equal(trimmed, []byte("null")) {
+		return nil
+	}
+
+	p.raw = trimmed
+
+	known := jsonFieldNames(reflect.TypeOf(v).Elem())
+
+	// data was validated by json.Unmarshal above, so its fields can be walked without decoding them again
+	return eachField(trimmed, func(key, value []byte) error {
+		name := string(key[1 : len(key)-1])
+		if bytes.IndexByte(key, '\\') >= 0 {
+			if err := json.Unmarshal(key, &name); err != nil {
+				return err
+			}
+		}
+
+		if known[name] {
+			return nil
+		}
+
+		if p.extra == nil {
+			p.extra = make(map[string]json.RawMessage)
+		}
+
+		p.extra[name] = value
+
+		return nil
+	})
+}
+
+// eachField calls fn with the quoted key and the raw value of every field of the valid JSON object data.
+func eachField(data []byte, fn func(key, value []byte) error) error {
+	i := skipSpace(data, 0)
+	if i >= len(data) || data[i] != '{' {
+		return nil
+	}
+
+	for i++; ; {
+		i = skipSpace(data, i)
+		if i >= len(data) || data[i] == '}' {
+			return nil
+		}
+
+		if data[i] == ',' {
+			i = skipSpace(data, i+1)
+		}
+
+		keyEnd := skipString(data, i)
+		key := data[i:keyEnd]
+
+		i = skipSpace(data, skipSpace(data, keyEnd)+1) // past the colon
+
+		valueEnd := skipValue(data, i)
+		if err := fn(key, data[i:valueEnd]); err != nil {
+			return err
+		}
+
+		i = valueEnd
+	}
+}
+
+func skipSpace(data []byte, i int) int {
+	for i < len(data) && (data[i] == ' ' || data[i] == '\t' || data[i] == '\n' || data[i] == '\r') {
+		i++
+	}
+
+	return i
+}
+
+// skipString returns the index after the JSON string starting at data[i].
+func skipString(data []byte, i int) int {
+	for j := i + 1; j < len(data); j++ {
+		switch data[j] {
+		case '\\':
+			j++
+		case '"':
+			return j + 1
+		}
+	}
+
+	return len(data)
+}
+
+// skipValue returns the index after the JSON value starting at data[i].
+func skipValue(data []byte, i int) int {
+	switch data[i] {
+	case '"':
+		return skipString(data, i)
+	case '{', '[':
+		depth := 0
+
+		for j := i; j < len(data); j++ {
+			switch data[j] {
+			case '"':
+				j = skipString(data, j) - 1
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+				if depth == 0 {
+					return j + 1
+				}
+			}
+		}
+
+		return len(data)
+	}
+
+	for j := i; j < len(data); j++ {
+		switch data[j] {
+		case ',', '}', ']', ' ', '\t', '\n', '\r':
+			return j
+		}
+	}
+
+	return len(data)
+}
+
+func (r *IssueResp) UnmarshalJSON(data []byte) error {
+	type plain IssueResp
+
+	return unmarshalPreserving(data, (*plain)(r), &r.payload)
+}
+
+func (s *StorySet) UnmarshalJSON(data []byte) error {
+	type plain StorySet
+
+	return unmarshalPreserving(data, (*plain)(s), &s.payload)
+}
+
+func (r *SeriesResp) UnmarshalJSON(data []byte) error {
+	type plain SeriesResp
+
+	return unmarshalPreserving(data, (*plain)(r), &r.payload)
+}
+
+func (s *SeriesInstance) UnmarshalJSON(data []byte) error {
+	type plain SeriesInstance
+
+	return unmarshalPreserving(data, (*plain)(s), &s.payload)
+}
+
+func (p *Publisher) UnmarshalJSON(data []byte) error {
+	type plain Publisher
+
+	return unmarshalPreserving(data, (*plain)(p), &p.payload)
+}
+
+func (r *PublisherResp) UnmarshalJSON(data []byte) error {
+	type plain PublisherResp
+
+	return unmarshalPreserving(data, (*plain)(r), &r.payload)
+}
+
+func (b *Brand) UnmarshalJSON(data []byte) error {
+	type plain Brand
+
+	return unmarshalPreserving(data, (*plain)(b), &b.payload)
+}
+
+func (r *BrandResp) UnmarshalJSON(data []byte) error {
+	type plain BrandResp
+
+	return unmarshalPreserving(data, (*plain)(r), &r.payload)
+}
+
+func (g *BrandGroup) UnmarshalJSON(data []byte) error {
+	type plain BrandGroup
+
+	return unmarshalPreserving(data, (*plain)(g), &g.payload)
+}
+
+func (r *BrandGroupResp) UnmarshalJSON(data []byte) error {
+	type plain BrandGroupResp
+
+	return unmarshalPreserving(data, (*plain)(r), &r.payload)
+}
+
+func (p *IndiciaPublisher) UnmarshalJSON(data []byte) error {
+	type plain IndiciaPublisher
+
+	return unmarshalPreserving(data, (*plain)(p), &p.payload)
+}
+
+func (r *IndiciaPublisherResp) UnmarshalJSON(data []byte) error {
+	type plain IndiciaPublisherResp
+
+	return unmarshalPreserving(data, (*plain)(r), &r.payload)
+}
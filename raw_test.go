@@ -0,0 +1,125 @@
+package gcd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIssueResp_RawExtra(t *testing.T) {
+	t.Parallel()
+
+	var issue IssueResp
+	require.NoError(t, json.Unmarshal([]byte(superman2023_1Issue), &issue))
+
+	assert.JSONEq(t, superman2023_1Issue, string(issue.Raw()))
+	assert.Empty(t, issue.Extra())
+	assert.Equal(t, "April 2023", issue.PublicationDate, "known fields are still decoded")
+
+	require.Len(t, issue.StorySet, 4)
+	assert.Contains(t, string(issue.StorySet[1].Raw()), "Voices in Your Head")
+}
+
+func TestAPI_RawExtra(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintln(w, `{
+			"count": 1,
+			"next": null,
+			"total_pages": 1,
+			"results": [{"name": "Superman", "year_began": 2023, "keywords": ["kryptonian", "metropolis"]}]
+		}`)
+	}))
+	t.Cleanup(server.Close)
+
+	api := API{
+		Prefix: "http://" + server.Listener.Addr().String() + "/api/",
+	}
+
+	resp, err := api.Series(context.Background(), SeriesReq{Name: "Superman"})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]json.RawMessage{"total_pages": json.RawMessage("1")}, resp.Extra())
+	assert.JSONEq(t, `{
+		"count": 1,
+		"next": null,
+		"total_pages": 1,
+		"results": [{"name": "Superman", "year_began": 2023, "keywords": ["kryptonian", "metropolis"]}]
+	}`, string(resp.Raw()), "the payload as received")
+
+	require.Len(t, resp.Results, 1)
+	series := resp.Results[0]
+	assert.Equal(t, "Superman", series.Name)
+	assert.Equal(t, 2023, series.YearBegan)
+	assert.JSONEq(t, `["kryptonian", "metropolis"]`, string(series.Extra()["keywords"]))
+}
+
+func TestUnmarshalPreserving_null(t *testing.T) {
+	t.Parallel()
+
+	var resp struct {
+		Issue *IssueResp `json:"issue"`
+		Brand Brand      `json:"brand"`
+	}
+
+	require.NoError(t, json.Unmarshal([]byte(`{"issue": null, "brand": null}`), &resp))
+	assert.Nil(t, resp.Issue)
+	assert.Nil(t, resp.Brand.Raw())
+}
+
+func TestUnmarshalPreserving_extra(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(` {"name": "Superman", "year_began": 1939, "named": {"a": [1, "]}"]}, "notes":"x", "score": -1.5e3 , "tags":[] } `)
+
+	var series SeriesInstance
+	require.NoError(t, json.Unmarshal(data, &series))
+
+	assert.Equal(t, "Superman", series.Name)
+	assert.Equal(t, map[string]json.RawMessage{
+		"named": json.RawMessage(`{"a": [1, "]}"]}`),
+		"score": json.RawMessage(`-1.5e3`),
+		"tags":  json.RawMessage(`[]`),
+	}, series.Extra())
+	assert.Equal(t, string(bytes.TrimSpace(data)), string(series.Raw()))
+}
+
+func TestUnmarshalPreserving_reusedDecoder(t *testing.T) {
+	t.Parallel()
+
+	var jsonl bytes.Buffer
+	for i := range 200 {
+		fmt.Fprintf(&jsonl, `{"series_name": "Superman", "new_field": %d, "story_set": [{"type": "cover", "x": %d}]}`+"\n", i, i)
+	}
+
+	// small reads make the decoder compact and refill its buffer between values
+	dec := json.NewDecoder(iotest.OneByteReader(&jsonl))
+
+	var issues []IssueResp
+
+	for dec.More() {
+		var issue IssueResp
+		require.NoError(t, dec.Decode(&issue))
+
+		issues = append(issues, issue)
+	}
+
+	require.Len(t, issues, 200)
+
+	for i, issue := range issues {
+		assert.Equal(t, fmt.Sprint(i), string(issue.Extra()["new_field"]), "values keep their own copy of the decoder buffer")
+		assert.Contains(t, string(issue.Raw()), fmt.Sprintf(`"new_field": %d,`, i))
+		require.Len(t, issue.StorySet, 1)
+		assert.Equal(t, fmt.Sprint(i), string(issue.StorySet[0].Extra()["x"]))
+	}
+}
@@ -0,0 +1,126 @@
+package gcd
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"time"
+)
+
+// RetryPolicy controls how API.req retries failed requests. A nil policy on API disables retries.
+type RetryPolicy struct {
+	MaxAttempts int           // total attempts including the first one; values below 2 disable retries
+	BaseDelay   time.Duration // delay before the first retry, doubled on every following attempt
+	MaxDelay    time.Duration // upper bound for the backoff; a longer Retry-After ends the retries. Zero means no bound
+	Jitter      float64       // fraction (0-1) of the delay that is randomized
+
+	RetryableStatuses  []int // defaults to 429, 502, 503 and 504 when nil
+	RetryNetworkErrors bool  // retry when the HTTPDoer itself returns an error
+}
+
+var defaultRetryableStatuses = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// DefaultRetryPolicy returns a policy suitable for the public comics.org api.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:        5,
+		BaseDelay:          500 * time.Millisecond,
+		MaxDelay:           30 * time.Second,
+		Jitter:             0.2,
+		RetryNetworkErrors: true,
+	}
+}
+
+func (p *RetryPolicy) attempts() int {
+	if p == nil || p.MaxAttempts < 1 {
+		return 1
+	}
+
+	return p.MaxAttempts
+}
+
+func (p *RetryPolicy) retryableStatus(code int) bool {
+	statuses := p.RetryableStatuses
+	if statuses == nil {
+		statuses = defaultRetryableStatuses
+	}
+
+	return slices.Contains(statuses, code)
+}
+
+func (p *RetryPolicy) retryableError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	return p.RetryNetworkErrors
+}
+
+// maxBackoff is where the doubling saturates when MaxDelay does not bound it.
+const maxBackoff = time.Duration(math.MaxInt64)
+
+// backoff returns the delay before retry number attempt (starting at 1).
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	limit := maxBackoff
+	if p.MaxDelay > 0 {
+		limit = p.MaxDelay
+	}
+
+	delay := p.BaseDelay
+	for i := 1; i < attempt && delay < limit; i++ {
+		if delay > limit/2 {
+			delay = limit
+
+			break
+		}
+
+		delay *= 2
+	}
+
+	delay = min(delay, limit)
+
+	if p.Jitter > 0 && delay > 0 {
+		jitter := min(p.Jitter, 1)
+		spread := float64(delay) * jitter
+		jittered := float64(delay) - spread + rand.Float64()*2*spread
+
+		// float64(maxBackoff) rounds up past the int64 range
+		if jittered < float64(maxBackoff) {
+			delay = time.Duration(jittered)
+		}
+	}
+
+	return delay
+}
+
+// sleepCtx waits for d or until ctx is done. It refuses to wait past the context deadline.
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < d {
+		return context.DeadlineExceeded
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// drain discards the rest of the body so the underlying connection can be reused.
+func drain(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	resp.Body.Close()
+}
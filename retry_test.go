@@ -0,0 +1,208 @@
+package gcd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	t.Parallel()
+
+	policy := &RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	assert.Equal(t, 100*time.Millisecond, policy.backoff(1))
+	assert.Equal(t, 200*time.Millisecond, policy.backoff(2))
+	assert.Equal(t, 800*time.Millisecond, policy.backoff(4))
+	assert.Equal(t, time.Second, policy.backoff(5))
+	assert.Equal(t, time.Second, policy.backoff(60))
+
+	policy.Jitter = 0.5
+	for range 100 {
+		d := policy.backoff(1)
+		assert.GreaterOrEqual(t, d, 50*time.Millisecond)
+		assert.LessOrEqual(t, d, 150*time.Millisecond)
+	}
+}
+
+func TestRetryPolicy_backoff_unbounded(t *testing.T) {
+	t.Parallel()
+
+	policy := &RetryPolicy{MaxAttempts: 100, BaseDelay: 100 * time.Millisecond, MaxDelay: 0}
+
+	previous := time.Duration(0)
+	for attempt := 1; attempt < policy.MaxAttempts; attempt++ {
+		d := policy.backoff(attempt)
+		assert.GreaterOrEqual(t, d, previous, "attempt %d", attempt)
+
+		previous = d
+	}
+
+	assert.Equal(t, maxBackoff, policy.backoff(99))
+
+	policy.Jitter = 0.5
+	for attempt := 60; attempt < policy.MaxAttempts; attempt++ {
+		assert.Positive(t, policy.backoff(attempt), "attempt %d", attempt)
+	}
+}
+
+func TestAPI_Retry(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch calls.Add(1) {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintln(w, supermanSeriesInstance)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	api := API{
+		Prefix: "http://" + server.Listener.Addr().String() + "/api/",
+		Retry:  &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond},
+	}
+
+	resp, err := api.SeriesInstance(context.Background(), 196803)
+	require.NoError(t, err)
+	assert.Equal(t, "Superman", resp.Name)
+	assert.EqualValues(t, 3, calls.Load())
+}
+
+func TestAPI_Retry_exhausted(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	t.Cleanup(server.Close)
+
+	api := API{
+		Prefix: "http://" + server.Listener.Addr().String() + "/api/",
+		Retry:  &RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond},
+	}
+
+	_, err := api.Issue(context.Background(), IssueReq{ID: 1})
+	require.ErrorIs(t, err, ErrServerError)
+	assert.EqualValues(t, 2, calls.Load())
+}
+
+func TestAPI_Retry_retryAfterBeyondMaxDelay(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "86400")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	t.Cleanup(server.Close)
+
+	api := API{
+		Prefix: "http://" + server.Listener.Addr().String() + "/api/",
+		Retry:  &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Second},
+	}
+
+	start := time.Now()
+
+	_, err := api.Issue(context.Background(), IssueReq{ID: 1})
+	require.ErrorIs(t, err, ErrRateLimited)
+	assert.EqualValues(t, 1, calls.Load(), "a day is longer than MaxDelay, so there is no retry")
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestAPI_Retry_notRetryable(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(server.Close)
+
+	api := API{
+		Prefix: "http://" + server.Listener.Addr().String() + "/api/",
+		Retry:  &RetryPolicy{MaxAttempts: 5, BaseDelay: time.Millisecond},
+	}
+
+	_, err := api.Issue(context.Background(), IssueReq{ID: 1})
+	require.ErrorIs(t, err, ErrNotFound)
+	assert.EqualValues(t, 1, calls.Load())
+}
+
+func TestAPI_Retry_networkErrors(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	errBoom := errors.New("boom")
+
+	api := API{
+		Prefix: TestPrefix,
+		Client: HTTPDoerFunc(func(*http.Request) (*http.Response, error) {
+			calls.Add(1)
+
+			return nil, errBoom
+		}),
+		Retry: &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond},
+	}
+
+	_, err := api.Issue(context.Background(), IssueReq{ID: 1})
+	require.ErrorIs(t, err, errBoom)
+	assert.EqualValues(t, 1, calls.Load(), "network errors are not retried by default")
+
+	calls.Store(0)
+	api.Retry.RetryNetworkErrors = true
+
+	_, err = api.Issue(context.Background(), IssueReq{ID: 1})
+	require.ErrorIs(t, err, errBoom)
+	assert.EqualValues(t, 3, calls.Load())
+}
+
+func TestAPI_Retry_respectsDeadline(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	t.Cleanup(server.Close)
+
+	api := API{
+		Prefix: "http://" + server.Listener.Addr().String() + "/api/",
+		Retry:  &RetryPolicy{MaxAttempts: 5, BaseDelay: time.Millisecond},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	start := time.Now()
+	_, err := api.Issue(ctx, IssueReq{ID: 1})
+	require.ErrorIs(t, err, ErrRateLimited)
+	assert.Less(t, time.Since(start), time.Second, "should not wait past the deadline")
+	assert.EqualValues(t, 1, calls.Load())
+}
@@ -2,31 +2,34 @@ package gcd
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
-	"net/http"
 	"strconv"
-	"strings"
 )
 
 type SeriesInstance struct {
-	APIURL           string   `json:"api_url"`
-	Name             string   `json:"name"`
-	Country          string   `json:"country"`
-	Language         string   `json:"language"`
-	ActiveIssues     []string `json:"active_issues"`
-	IssueDescriptors []string `json:"issue_descriptors"`
-	Color            string   `json:"color"`
-	Dimensions       string   `json:"dimensions"`
-	PaperStock       string   `json:"paper_stock"`
-	Binding          string   `json:"binding"`
-	PublishingFormat string   `json:"publishing_format"`
-	Notes            string   `json:"notes"`
-	YearBegan        int      `json:"year_began"`
-	YearEnded        int      `json:"year_ended"`
-	Publisher        string   `json:"publisher"`
+	APIURL           string            `json:"api_url"`
+	Name             string            `json:"name"`
+	Country          string            `json:"country"`
+	Language         string            `json:"language"`
+	ActiveIssues     []Link[IssueResp] `json:"active_issues"`
+	IssueDescriptors []string          `json:"issue_descriptors"`
+	Color            string            `json:"color"`
+	Dimensions       string            `json:"dimensions"`
+	PaperStock       string            `json:"paper_stock"`
+	Binding          string            `json:"binding"`
+	PublishingFormat string            `json:"publishing_format"`
+	Notes            string            `json:"notes"`
+	YearBegan        int               `json:"year_began"`
+	YearEnded        Optional[int]     `json:"year_ended"`
+	Publisher        Link[Publisher]   `json:"publisher"`
+
+	payload
+}
+
+// IsOngoing reports whether the series is still being published, i.e. the api returned no end year.
+func (s SeriesInstance) IsOngoing() bool {
+	return !s.YearEnded.Valid
 }
 
 type SeriesReq struct {
@@ -48,46 +51,25 @@ func (r SeriesReq) URL(prefix string) (string, error) {
 		return "", errors.New("cannot specify both ID and Name")
 	}
 
-	url := prefix
-	if url[len(url)-1] == '/' {
-		url = url[:len(url)-1]
-	}
-
-	url += "/series"
+	segments := []string{"series"}
 
 	if r.ID > 0 {
-		url += "/" + strconv.Itoa(r.ID)
+		segments = append(segments, strconv.Itoa(r.ID))
 	}
 
 	if r.Name != "" {
-		url += "/name/" + r.Name
+		segments = append(segments, "name", r.Name)
 	}
 
 	if r.IssueNo > 0 {
-		url += "/issue/" + strconv.Itoa(r.IssueNo)
+		segments = append(segments, "issue", strconv.Itoa(r.IssueNo))
 	}
 
 	if r.Year > 0 {
-		url += "/year/" + strconv.Itoa(r.Year)
-	}
-
-	url += "/"
-
-	var params []string
-
-	if r.Format != "" {
-		params = append(params, "format="+r.Format)
+		segments = append(segments, "year", strconv.Itoa(r.Year))
 	}
 
-	if r.Page > 0 {
-		params = append(params, "page="+strconv.Itoa(r.Page))
-	}
-
-	if len(params) > 0 {
-		url += "?" + strings.Join(params, "&")
-	}
-
-	return url, nil
+	return buildURL(prefix, segments, requestQuery(r.Format, r.Page))
 }
 
 type SeriesResp struct {
@@ -95,40 +77,22 @@ type SeriesResp struct {
 	Next     string           `json:"next"`
 	Previous string           `json:"previous,omitempty"`
 	Results  []SeriesInstance `json:"results"`
+
+	payload
 }
 
 func (a API) SeriesFromURL(ctx context.Context, url string) (SeriesResp, error) {
 	var seriesResp SeriesResp
 
-	resp, err := a.req(ctx, url)
-	if err != nil {
+	if err := a.get(ctx, url, &seriesResp); err != nil {
 		return seriesResp, err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return seriesResp, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
-	}
-
-	data, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return seriesResp, fmt.Errorf("io.ReadAll: %w", err)
-	}
-
-	if err := json.Unmarshal(data, &seriesResp); err != nil {
-		return seriesResp, fmt.Errorf("json.Unmarshal: %w", err)
-	}
 
 	return seriesResp, nil
 }
 
 func (a API) Series(ctx context.Context, req SeriesReq) (SeriesResp, error) {
-	prefix := a.Prefix
-	if prefix == "" {
-		prefix = DefaultPrefix
-	}
-
-	uu, err := req.URL(prefix)
+	uu, err := req.URL(a.prefix())
 	if err != nil {
 		return SeriesResp{}, fmt.Errorf("failed to construct URL: %w", err)
 	}
@@ -139,33 +103,22 @@ func (a API) Series(ctx context.Context, req SeriesReq) (SeriesResp, error) {
 func (a API) SeriesInstanceFromURL(ctx context.Context, url string) (SeriesInstance, error) {
 	var seriesInstance SeriesInstance
 
-	resp, err := a.req(ctx, url)
-	if err != nil {
+	if err := a.get(ctx, url, &seriesInstance); err != nil {
 		return seriesInstance, err
 	}
 
-	defer resp.Body.Close()
-
-	data, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return seriesInstance, fmt.Errorf("io.ReadAll: %w", err)
-	}
-
-	if err := json.Unmarshal(data, &seriesInstance); err != nil {
-		return seriesInstance, fmt.Errorf("json.Unmarshal: %w", err)
-	}
-
 	return seriesInstance, nil
 }
 
 func (a API) SeriesInstance(ctx context.Context, id int) (SeriesInstance, error) {
-	uu := a.Prefix
-	if uu[len(uu)-1] == '/' {
-		uu = uu[:len(uu)-1]
+	if id <= 0 {
+		return SeriesInstance{}, errors.New("invalid ID")
 	}
 
-	uu += "/series/" + strconv.Itoa(id)
-	uu += "/"
+	uu, err := resourceURL(a.prefix(), "series", id, "", "", 0)
+	if err != nil {
+		return SeriesInstance{}, fmt.Errorf("failed to construct URL: %w", err)
+	}
 
 	return a.SeriesInstanceFromURL(ctx, uu)
 }
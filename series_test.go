@@ -5,6 +5,9 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -57,6 +60,50 @@ func TestSeriesReq_URL(t *testing.T) {
 			"https://example.org/api/series/7096/",
 			false,
 		},
+		{
+			"name-slash",
+			SeriesReq{
+				Name: "Spider-Man/Deadpool",
+			},
+			"https://example.org/api/series/name/Spider-Man%2FDeadpool/",
+			false,
+		},
+		{
+			"name-percent",
+			SeriesReq{
+				Name: "100% Marvel",
+				Page: 2,
+			},
+			"https://example.org/api/series/name/100%25%20Marvel/?page=2",
+			false,
+		},
+		{
+			"name-ampersand-question",
+			SeriesReq{
+				Name:   "Batman & Robin?",
+				Format: "json",
+			},
+			"https://example.org/api/series/name/Batman%20&%20Robin%3F/?format=json",
+			false,
+		},
+		{
+			"name-unicode",
+			SeriesReq{
+				Name: "Mônica & Cebolinha",
+				Year: 1970,
+			},
+			"https://example.org/api/series/name/M%C3%B4nica%20&%20Cebolinha/year/1970/",
+			false,
+		},
+		{
+			"id-and-name",
+			SeriesReq{
+				ID:   7096,
+				Name: "Batman",
+			},
+			"",
+			true,
+		},
 	}
 
 	for _, test := range tests {
@@ -77,6 +124,55 @@ func TestSeriesReq_URL(t *testing.T) {
 	}
 }
 
+func FuzzSeriesReq_URL(f *testing.F) {
+	for _, name := range []string{"Batman", "Spider-Man/Deadpool", "100% Marvel", "Batman & Robin", "?#;", ".", "..", "Mônica", "進撃の巨人", "a\x00b", "\xff"} {
+		f.Add(name, 0, 0)
+	}
+
+	f.Add("Superman", 1, 2023)
+
+	f.Fuzz(func(t *testing.T, name string, issueNo, year int) {
+		if name == "" {
+			t.Skip()
+		}
+
+		uu, err := SeriesReq{Name: name, IssueNo: issueNo, Year: year}.URL(TestPrefix)
+		require.NoError(t, err)
+
+		parsed, err := url.Parse(uu)
+		require.NoError(t, err)
+		assert.Equal(t, "example.org", parsed.Host)
+		assert.Empty(t, parsed.RawQuery)
+		assert.Empty(t, parsed.Fragment)
+
+		segments := strings.Split(strings.Trim(parsed.EscapedPath(), "/"), "/")
+		require.GreaterOrEqual(t, len(segments), 4)
+
+		for _, segment := range segments {
+			assert.NotContains(t, []string{".", ".."}, segment, "dot segments are escaped")
+		}
+		assert.Equal(t, []string{"api", "series", "name"}, segments[:3])
+
+		got, err := url.PathUnescape(segments[3])
+		require.NoError(t, err)
+		assert.Equal(t, name, got, "the name round-trips as a single path segment")
+
+		rest := segments[4:]
+		if issueNo > 0 {
+			require.GreaterOrEqual(t, len(rest), 2)
+			assert.Equal(t, []string{"issue", strconv.Itoa(issueNo)}, rest[:2])
+			rest = rest[2:]
+		}
+
+		if year > 0 {
+			assert.Equal(t, []string{"year", strconv.Itoa(year)}, rest)
+			rest = nil
+		}
+
+		assert.Empty(t, rest)
+	})
+}
+
 func TestAPI_Series_SessionID(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,293 @@
+package gcd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// sessionRefreshMargin is how long before its expiry a stored session is refreshed, or warned about when
+// there are no Credentials to refresh it with.
+const sessionRefreshMargin = 5 * time.Minute
+
+var ErrNoSession = errors.New("gcd: no session")
+
+// Session is a logged in gcd session.
+type Session struct {
+	ID      string    `json:"id"`      // value of the gcdsessionid cookie
+	Expires time.Time `json:"expires"` // zero if the server did not say
+}
+
+// Expired reports whether the session cookie has lapsed.
+func (s Session) Expired() bool {
+	return s.ExpiresWithin(0)
+}
+
+// ExpiresWithin reports whether the session cookie lapses within d. Sessions without a known expiry never do.
+func (s Session) ExpiresWithin(d time.Duration) bool {
+	return !s.Expires.IsZero() && time.Now().Add(d).After(s.Expires)
+}
+
+// SessionStore keeps the session shared by every copy of an API, and across restarts for persistent stores.
+// Implementations must be safe for concurrent use.
+type SessionStore interface {
+	Load() (Session, error) // ErrNoSession when nothing is stored
+	Save(session Session) error
+}
+
+// MemorySessionStore is a SessionStore shared by the API copies of a single process.
+type MemorySessionStore struct {
+	mu        sync.Mutex
+	session   Session
+	refreshMu sync.Mutex
+}
+
+func NewMemorySessionStore() *MemorySessionStore {
+	return &MemorySessionStore{}
+}
+
+func (s *MemorySessionStore) Load() (Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.session.ID == "" {
+		return Session{}, ErrNoSession
+	}
+
+	return s.session, nil
+}
+
+func (s *MemorySessionStore) Save(session Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.session = session
+
+	return nil
+}
+
+func (s *MemorySessionStore) refreshLock() *sync.Mutex {
+	return &s.refreshMu
+}
+
+// FileSessionStore is a SessionStore keeping the session in a JSON file readable only by its owner.
+type FileSessionStore struct {
+	path      string
+	refreshMu *sync.Mutex // shared by the stores of the same file
+}
+
+// fileSessionLocks holds the refresh lock of each session file, keyed by absolute path.
+var fileSessionLocks sync.Map // string -> *sync.Mutex
+
+// NewFileSessionStore returns a store keeping the session at path. Its directory is created if needed.
+func NewFileSessionStore(path string) (*FileSessionStore, error) {
+	if path == "" {
+		return nil, errors.New("empty session file path")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("os.MkdirAll: %w", err)
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("filepath.Abs: %w", err)
+	}
+
+	mu, _ := fileSessionLocks.LoadOrStore(abs, &sync.Mutex{})
+
+	return &FileSessionStore{path: path, refreshMu: mu.(*sync.Mutex)}, nil
+}
+
+func (s *FileSessionStore) Load() (Session, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Session{}, ErrNoSession
+	}
+
+	if err != nil {
+		return Session{}, fmt.Errorf("os.ReadFile: %w", err)
+	}
+
+	var session Session
+	if err := json.Unmarshal(data, &session); err != nil {
+		return Session{}, fmt.Errorf("json.Unmarshal: %w", err)
+	}
+
+	if session.ID == "" {
+		return Session{}, ErrNoSession
+	}
+
+	return session, nil
+}
+
+func (s *FileSessionStore) Save(session Session) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return fmt.Errorf("json.Marshal: %w", err)
+	}
+
+	if err := writeFileAtomic(s.path, data); err != nil {
+		return fmt.Errorf("saving session: %w", err)
+	}
+
+	return nil
+}
+
+func (s *FileSessionStore) refreshLock() *sync.Mutex {
+	if s.refreshMu == nil {
+		return &foreignStoreMu // not built by NewFileSessionStore
+	}
+
+	return s.refreshMu
+}
+
+// sessionLocker is implemented by the stores of this package, which keep API copies sharing them from logging
+// in at the same time.
+type sessionLocker interface {
+	refreshLock() *sync.Mutex
+}
+
+// foreignStoreMu serializes the logins of the API copies using stores implemented outside this package.
+var foreignStoreMu sync.Mutex
+
+func sessionLock(store SessionStore) *sync.Mutex {
+	if locker, ok := store.(sessionLocker); ok {
+		return locker.refreshLock()
+	}
+
+	return &foreignStoreMu
+}
+
+// resolveSession returns a copy of the API using the session from Sessions, logged in again first when it is
+// about to expire and Credentials are set. An empty store is seeded with SessionID.
+func (a API) resolveSession(ctx context.Context) (API, error) {
+	if a.Sessions == nil {
+		return a, nil
+	}
+
+	session, err := a.loadSession()
+	if err != nil {
+		return a, err
+	}
+
+	if session.ID == "" && a.SessionID != "" {
+		if session, err = a.seedSession(); err != nil {
+			return a, err
+		}
+	}
+
+	switch {
+	case session.ID == "":
+		// anonymous until the server asks for a session, see fetch
+		return a, nil
+	case !session.ExpiresWithin(sessionRefreshMargin):
+	case a.Credentials != nil:
+		if session, err = a.refreshSession(ctx, session.ID); err != nil {
+			return a, err
+		}
+	case a.logEnabled(ctx, slog.LevelWarn):
+		a.Logger.LogAttrs(ctx, slog.LevelWarn, "gcd session expiring",
+			slog.Time("expires", session.Expires),
+			slog.Bool("expired", session.Expired()),
+		)
+	}
+
+	a.SessionID = session.ID
+
+	return a, nil
+}
+
+// seedSession stores SessionID in the empty store, unless another copy of the API filled it meanwhile.
+func (a API) seedSession() (Session, error) {
+	mu := sessionLock(a.Sessions)
+	mu.Lock()
+	defer mu.Unlock()
+
+	session, err := a.loadSession()
+	if err != nil || session.ID != "" {
+		return session, err
+	}
+
+	session = Session{ID: a.SessionID}
+
+	return session, a.saveSession(session)
+}
+
+func (a API) loadSession() (Session, error) {
+	session, err := a.Sessions.Load()
+	if errors.Is(err, ErrNoSession) {
+		return Session{}, nil
+	}
+
+	if err != nil {
+		return Session{}, fmt.Errorf("SessionStore.Load: %w", err)
+	}
+
+	return session, nil
+}
+
+// refreshSession logs in with Credentials and stores the new session, unless another copy of the API
+// already replaced the stale one.
+func (a API) refreshSession(ctx context.Context, stale string) (Session, error) {
+	if a.Sessions != nil {
+		mu := sessionLock(a.Sessions)
+		mu.Lock()
+		defer mu.Unlock()
+
+		current, err := a.loadSession()
+		if err != nil {
+			return Session{}, err
+		}
+
+		if current.ID != "" && current.ID != stale && !current.ExpiresWithin(sessionRefreshMargin) {
+			return current, nil
+		}
+	}
+
+	session, err := a.authenticate(ctx)
+	if err != nil {
+		return Session{}, err
+	}
+
+	if err := a.saveSession(session); err != nil {
+		return Session{}, err
+	}
+
+	return session, nil
+}
+
+func (a API) saveSession(session Session) error {
+	if a.Sessions == nil {
+		return nil
+	}
+
+	if err := a.Sessions.Save(session); err != nil {
+		return fmt.Errorf("SessionStore.Save: %w", err)
+	}
+
+	return nil
+}
+
+// CurrentSession returns the session requests are sent with, from Sessions when set.
+func (a API) CurrentSession() (Session, error) {
+	if a.Sessions != nil {
+		session, err := a.loadSession()
+		if err != nil || session.ID != "" {
+			return session, err
+		}
+	}
+
+	if a.SessionID == "" {
+		return Session{}, ErrNoSession
+	}
+
+	return Session{ID: a.SessionID}, nil
+}
@@ -0,0 +1,229 @@
+package gcd
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSession_ExpiresWithin(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, Session{ID: "a"}.Expired())
+	assert.False(t, Session{ID: "a"}.ExpiresWithin(time.Hour))
+
+	soon := Session{ID: "a", Expires: time.Now().Add(time.Minute)}
+	assert.False(t, soon.Expired())
+	assert.True(t, soon.ExpiresWithin(time.Hour))
+
+	assert.True(t, Session{ID: "a", Expires: time.Now().Add(-time.Minute)}.Expired())
+}
+
+func TestMemorySessionStore(t *testing.T) {
+	t.Parallel()
+
+	store := NewMemorySessionStore()
+
+	_, err := store.Load()
+	require.ErrorIs(t, err, ErrNoSession)
+
+	session := Session{ID: "abc", Expires: time.Now().Add(time.Hour)}
+	require.NoError(t, store.Save(session))
+
+	loaded, err := store.Load()
+	require.NoError(t, err)
+	assert.Equal(t, session, loaded)
+}
+
+func TestFileSessionStore(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "gcd", "session.json")
+
+	store, err := NewFileSessionStore(path)
+	require.NoError(t, err)
+
+	_, err = store.Load()
+	require.ErrorIs(t, err, ErrNoSession)
+
+	session := Session{ID: "abc", Expires: time.Now().Add(time.Hour).Truncate(time.Second)}
+	require.NoError(t, store.Save(session))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	// a new store on the same file, as after a restart
+	store, err = NewFileSessionStore(path)
+	require.NoError(t, err)
+
+	loaded, err := store.Load()
+	require.NoError(t, err)
+	assert.Equal(t, session.ID, loaded.ID)
+	assert.True(t, session.Expires.Equal(loaded.Expires))
+
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+
+	_, err = store.Load()
+	require.Error(t, err)
+	require.NotErrorIs(t, err, ErrNoSession)
+
+	_, err = NewFileSessionStore("")
+	require.Error(t, err)
+}
+
+func TestSessionLock(t *testing.T) {
+	t.Parallel()
+
+	a, b := NewMemorySessionStore(), NewMemorySessionStore()
+
+	assert.Same(t, sessionLock(a), sessionLock(a))
+	assert.NotSame(t, sessionLock(a), sessionLock(b), "stores do not wait for each other")
+
+	path := filepath.Join(t.TempDir(), "session.json")
+
+	file, err := NewFileSessionStore(path)
+	require.NoError(t, err)
+
+	sameFile, err := NewFileSessionStore(path)
+	require.NoError(t, err)
+
+	assert.Same(t, sessionLock(file), sessionLock(sameFile), "stores of the same file share the lock")
+}
+
+func TestAPI_Sessions_shared(t *testing.T) {
+	t.Parallel()
+
+	gcd := newFakeGCD(t)
+
+	store := NewMemorySessionStore()
+	require.NoError(t, store.Save(Session{ID: "expired"}))
+
+	api := API{
+		Prefix:      gcd.prefix(),
+		Credentials: StaticCredentials{Username: "jane", Password: "secret"},
+		Sessions:    store,
+	}
+
+	var wg sync.WaitGroup
+
+	for range 8 {
+		wg.Add(1)
+
+		go func(copied API) {
+			defer wg.Done()
+
+			_, err := copied.Issue(context.Background(), IssueReq{ID: 2495111})
+			assert.NoError(t, err)
+		}(api)
+	}
+
+	wg.Wait()
+
+	assert.EqualValues(t, 1, gcd.logins.Load(), "one copy logs in, the others pick up the stored session")
+
+	session, err := store.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "session-1", session.ID)
+	assert.False(t, session.Expires.IsZero())
+	assert.Empty(t, api.SessionID)
+}
+
+func TestAPI_Sessions_seed(t *testing.T) {
+	t.Parallel()
+
+	gcd := newFakeGCD(t)
+
+	loggedIn := API{Prefix: gcd.prefix()}
+	require.NoError(t, loggedIn.Login(context.Background(), "jane", "secret"))
+
+	store := NewMemorySessionStore()
+
+	api := API{
+		Prefix:      gcd.prefix(),
+		SessionID:   loggedIn.SessionID,
+		Credentials: StaticCredentials{Username: "jane", Password: "secret"},
+		Sessions:    store,
+	}
+
+	_, err := api.Issue(context.Background(), IssueReq{ID: 2495111})
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, gcd.logins.Load(), "the valid SessionID is used instead of logging in")
+
+	session, err := store.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "session-1", session.ID)
+}
+
+func TestAPI_Sessions_refreshBeforeExpiry(t *testing.T) {
+	t.Parallel()
+
+	gcd := newFakeGCD(t)
+
+	api := API{
+		Prefix:      gcd.prefix(),
+		Credentials: StaticCredentials{Username: "jane", Password: "secret"},
+		Sessions:    NewMemorySessionStore(),
+	}
+
+	require.NoError(t, api.Authenticate(context.Background()))
+
+	current, err := api.CurrentSession()
+	require.NoError(t, err)
+	assert.Equal(t, "session-1", current.ID)
+
+	// still valid on the server, but about to lapse
+	require.NoError(t, api.Sessions.Save(Session{ID: "session-1", Expires: time.Now().Add(time.Minute)}))
+
+	_, err = api.Issue(context.Background(), IssueReq{ID: 2495111})
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, gcd.logins.Load())
+
+	current, err = api.CurrentSession()
+	require.NoError(t, err)
+	assert.Equal(t, "session-2", current.ID)
+}
+
+func TestAPI_Sessions_warnBeforeExpiry(t *testing.T) {
+	t.Parallel()
+
+	gcd := newFakeGCD(t)
+
+	api := API{Prefix: gcd.prefix(), Sessions: NewMemorySessionStore()}
+	require.NoError(t, api.Login(context.Background(), "jane", "secret"))
+	require.NoError(t, api.Sessions.Save(Session{ID: api.SessionID, Expires: time.Now().Add(time.Minute)}))
+
+	var buf bytes.Buffer
+
+	copied := API{Prefix: gcd.prefix(), Sessions: api.Sessions}
+	copied.Logger = slog.New(slog.NewJSONHandler(&buf, nil))
+
+	_, err := copied.Issue(context.Background(), IssueReq{ID: 2495111})
+	require.NoError(t, err, "the stored session is used by the copy")
+
+	records := decodeLogRecords(t, &buf)
+	require.Len(t, records, 2)
+	assert.Equal(t, "gcd session expiring", records[0]["msg"])
+	assert.Equal(t, "WARN", records[0]["level"])
+	assert.Equal(t, false, records[0]["expired"])
+	assert.Equal(t, true, records[1]["authenticated"])
+}
+
+func TestAPI_CurrentSession(t *testing.T) {
+	t.Parallel()
+
+	_, err := API{}.CurrentSession()
+	require.ErrorIs(t, err, ErrNoSession)
+
+	session, err := API{SessionID: "abc", Sessions: NewMemorySessionStore()}.CurrentSession()
+	require.NoError(t, err)
+	assert.Equal(t, "abc", session.ID)
+}
@@ -0,0 +1,131 @@
+package gcd
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// SchemaDriftError is returned in strict mode when a payload does not match the response type exactly.
+// The response is still decoded as usual and returned alongside the error.
+type SchemaDriftError struct {
+	URL     string
+	Unknown []string // field paths present in the payload but not in the response type, e.g. "story_set[].isbn"
+	Missing []string // field paths of the response type absent from the payload
+}
+
+func (e *SchemaDriftError) Error() string {
+	var parts []string
+
+	if len(e.Unknown) > 0 {
+		parts = append(parts, "unknown fields: "+strings.Join(e.Unknown, ", "))
+	}
+
+	if len(e.Missing) > 0 {
+		parts = append(parts, "missing fields: "+strings.Join(e.Missing, ", "))
+	}
+
+	return fmt.Sprintf("gcd: schema drift for %s: %s", e.URL, strings.Join(parts, "; "))
+}
+
+var (
+	jsonUnmarshalerType  = reflect.TypeFor[json.Unmarshaler]()
+	payloadPreserverType = reflect.TypeFor[payloadPreserver]()
+)
+
+// checkSchema compares the JSON payload in data with the fields of v, which must be a pointer to the
+// response type. It returns nil when they match.
+func checkSchema(url string, data []byte, v any) error {
+	var payload any
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return fmt.Errorf("json.Unmarshal: %w", err)
+	}
+
+	drift := &SchemaDriftError{URL: url}
+	compareSchema(reflect.TypeOf(v).Elem(), payload, "", drift)
+
+	if len(drift.Unknown) == 0 && len(drift.Missing) == 0 {
+		return nil
+	}
+
+	slices.Sort(drift.Unknown)
+	drift.Unknown = slices.Compact(drift.Unknown)
+	slices.Sort(drift.Missing)
+	drift.Missing = slices.Compact(drift.Missing)
+
+	return drift
+}
+
+func compareSchema(t reflect.Type, payload any, path string, drift *SchemaDriftError) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	// types decoding themselves are opaque, except for response types which only preserve their payload
+	if payload == nil || (reflect.PointerTo(t).Implements(jsonUnmarshalerType) &&
+		!reflect.PointerTo(t).Implements(payloadPreserverType)) {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		if items, ok := payload.([]any); ok {
+			for _, item := range items {
+				compareSchema(t.Elem(), item, path+"[]", drift)
+			}
+		}
+	case reflect.Struct:
+		object, ok := payload.(map[string]any)
+		if !ok {
+			return
+		}
+
+		known := make(map[string]bool)
+
+		for i := range t.NumField() {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+
+			tag := field.Tag.Get("json")
+			if tag == "-" {
+				continue
+			}
+
+			name, opts, _ := strings.Cut(tag, ",")
+			if name == "" {
+				name = field.Name
+			}
+
+			known[name] = true
+
+			value, present := object[name]
+			if !present {
+				if !strings.Contains(opts, "omitempty") {
+					drift.Missing = append(drift.Missing, joinPath(path, name))
+				}
+
+				continue
+			}
+
+			compareSchema(field.Type, value, joinPath(path, name), drift)
+		}
+
+		for name := range object {
+			if !known[name] {
+				drift.Unknown = append(drift.Unknown, joinPath(path, name))
+			}
+		}
+	}
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+
+	return path + "." + name
+}
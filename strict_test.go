@@ -0,0 +1,67 @@
+package gcd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckSchema(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, checkSchema("", []byte(superman2023_1Issue), &IssueResp{}))
+	require.NoError(t, checkSchema("", []byte(supermanSeriesList), &SeriesResp{}))
+
+	err := checkSchema("https://example.org/api/issue/1/", []byte(`{
+		"api_url": "", "series_name": "", "descriptor": "", "publication_date": "", "price": "",
+		"page_count": "", "editing": "", "brand": "", "isbn": "", "barcode": "", "rating": "",
+		"on_sale_date": "", "notes": "", "variant_of": null, "series": "", "cover": "",
+		"indicia_publisher": "", "keywords": "",
+		"story_set": [
+			{"type": "cover", "first_line": ""},
+			{"type": "comic story", "first_line": ""}
+		]
+	}`), &IssueResp{})
+
+	var drift *SchemaDriftError
+	require.ErrorAs(t, err, &drift)
+
+	assert.Equal(t, "https://example.org/api/issue/1/", drift.URL)
+	assert.Equal(t, []string{"keywords", "story_set[].first_line"}, drift.Unknown)
+	assert.Contains(t, drift.Missing, "indicia_frequency")
+	assert.Contains(t, drift.Missing, "story_set[].title")
+	assert.NotContains(t, drift.Missing, "story_set[].type")
+	assert.Contains(t, err.Error(), "keywords")
+}
+
+func TestAPI_Strict(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintln(w, `{"count": 1, "next": null, "previous": null, "results": [], "total_pages": 1}`)
+	}))
+	t.Cleanup(server.Close)
+
+	api := API{
+		Prefix: "http://" + server.Listener.Addr().String() + "/api/",
+	}
+
+	_, err := api.Series(context.Background(), SeriesReq{Name: "Batman"})
+	require.NoError(t, err, "lenient by default")
+
+	api.Strict = true
+
+	resp, err := api.Series(context.Background(), SeriesReq{Name: "Batman"})
+
+	var drift *SchemaDriftError
+	require.ErrorAs(t, err, &drift)
+	assert.Equal(t, []string{"total_pages"}, drift.Unknown)
+	assert.Empty(t, drift.Missing)
+	assert.Equal(t, 1, resp.Count, "response is still decoded")
+}
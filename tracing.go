@@ -0,0 +1,199 @@
+package gcd
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptrace"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Tracer starts spans around API calls and the HTTP attempts made for them. It mirrors the small part of the
+// OpenTelemetry tracing API the client needs, so an adapter to go.opentelemetry.io/otel/trace only has to
+// forward the calls, while this package stays free of the dependency.
+type Tracer interface {
+	Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span)
+}
+
+// Span is a single traced operation.
+type Span interface {
+	SetAttributes(attrs ...Attribute)
+	AddEvent(name string, attrs ...Attribute)
+	RecordError(err error)
+	End()
+}
+
+// Attribute is a key/value pair attached to a span. Value is a string, bool, int, int64, float64 or time.Duration.
+type Attribute struct {
+	Key   string
+	Value any
+}
+
+func attr(key string, value any) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// resourceFromURL extracts the resource name and ID from an api URL, e.g. ("issue", 42) from
+// "https://www.comics.org/api/issue/42/". The ID is zero for listings.
+func resourceFromURL(rawURL string) (string, int) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", 0
+	}
+
+	segments := strings.Split(strings.Trim(u.EscapedPath(), "/"), "/")
+	for i, segment := range segments {
+		if segment != "api" || i+1 >= len(segments) {
+			continue
+		}
+
+		resource := segments[i+1]
+		if i+2 < len(segments) {
+			if id, err := strconv.Atoi(segments[i+2]); err == nil {
+				return resource, id
+			}
+		}
+
+		return resource, 0
+	}
+
+	return "", 0
+}
+
+// startCallSpan starts the span covering a whole API call.
+func (a API) startCallSpan(ctx context.Context, url string) (context.Context, Span) {
+	if a.Tracer == nil {
+		return ctx, nil
+	}
+
+	resource, id := resourceFromURL(url)
+
+	attrs := []Attribute{attr("gcd.resource", resource), attr("url.full", url)}
+	if id > 0 {
+		attrs = append(attrs, attr("gcd.id", id))
+	}
+
+	return a.Tracer.Start(ctx, "gcd."+resource, attrs...)
+}
+
+// endCallSpan records the outcome of the call on span, which may be nil.
+func endCallSpan(span Span, call *callInfo, err error) {
+	if span == nil {
+		return
+	}
+
+	attrs := []Attribute{attr("gcd.attempts", call.attempts)}
+	if call.status != 0 {
+		attrs = append(attrs, attr("http.status_code", call.status))
+	}
+
+	if call.cache != cacheNone {
+		attrs = append(attrs, attr("gcd.cache", string(call.cache)))
+	}
+
+	span.SetAttributes(attrs...)
+
+	if err != nil {
+		span.RecordError(err)
+	}
+
+	span.End()
+}
+
+// startAttemptSpan starts the span of a single HTTP attempt, with connection timings recorded as events.
+func (a API) startAttemptSpan(ctx context.Context, url string, attempt int) (context.Context, Span) {
+	if a.Tracer == nil {
+		return ctx, nil
+	}
+
+	ctx, span := a.Tracer.Start(ctx, "HTTP GET",
+		attr("http.method", "GET"),
+		attr("url.full", url),
+		attr("gcd.attempt", attempt),
+	)
+
+	return httptrace.WithClientTrace(ctx, clientTrace(span)), span
+}
+
+func endAttemptSpan(span Span, resp *http.Response, err error) {
+	if span == nil {
+		return
+	}
+
+	if err != nil {
+		span.RecordError(err)
+	} else {
+		span.SetAttributes(attr("http.status_code", resp.StatusCode))
+	}
+
+	span.End()
+}
+
+// clientTrace reports DNS, connect and TLS timings of an HTTP attempt as span events. Its hooks may run
+// concurrently: dials to several addresses race each other with Happy Eyeballs, so connect timings are kept per
+// address.
+func clientTrace(span Span) *httptrace.ClientTrace {
+	var (
+		mu            sync.Mutex
+		dnsStart      time.Time
+		tlsStart      time.Time
+		connectStarts = make(map[string]time.Time)
+	)
+
+	since := func(start *time.Time) time.Duration {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return time.Since(*start)
+	}
+
+	return &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			span.AddEvent("http.got_conn", attr("net.reused", info.Reused), attr("net.idle_time", info.IdleTime))
+		},
+		DNSStart: func(httptrace.DNSStartInfo) {
+			mu.Lock()
+			dnsStart = time.Now()
+			mu.Unlock()
+		},
+		DNSDone: func(info httptrace.DNSDoneInfo) {
+			span.AddEvent("http.dns", attr("duration", since(&dnsStart)), attr("error", info.Err != nil))
+		},
+		ConnectStart: func(network, addr string) {
+			mu.Lock()
+			connectStarts[network+" "+addr] = time.Now()
+			mu.Unlock()
+		},
+		ConnectDone: func(network, addr string, err error) {
+			mu.Lock()
+			start := connectStarts[network+" "+addr]
+			delete(connectStarts, network+" "+addr)
+			mu.Unlock()
+
+			span.AddEvent("http.connect",
+				attr("duration", time.Since(start)),
+				attr("net.peer.addr", addr),
+				attr("error", err != nil),
+			)
+		},
+		TLSHandshakeStart: func() {
+			mu.Lock()
+			tlsStart = time.Now()
+			mu.Unlock()
+		},
+		TLSHandshakeDone: func(state tls.ConnectionState, err error) {
+			span.AddEvent("http.tls",
+				attr("duration", since(&tlsStart)),
+				attr("tls.protocol", state.NegotiatedProtocol),
+				attr("error", err != nil),
+			)
+		},
+		GotFirstResponseByte: func() {
+			span.AddEvent("http.first_byte")
+		},
+	}
+}
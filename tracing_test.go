@@ -0,0 +1,214 @@
+package gcd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordedSpan struct {
+	name       string
+	attrs      map[string]any
+	events     []string
+	eventAttrs []map[string]any
+	err        error
+	ended      bool
+}
+
+type recordingTracer struct {
+	mu    sync.Mutex
+	spans []*recordedSpan
+}
+
+func (r *recordingTracer) Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	span := &recordedSpan{name: name, attrs: make(map[string]any)}
+	for _, a := range attrs {
+		span.attrs[a.Key] = a.Value
+	}
+
+	r.spans = append(r.spans, span)
+
+	return ctx, &recordingSpan{tracer: r, span: span}
+}
+
+type recordingSpan struct {
+	tracer *recordingTracer
+	span   *recordedSpan
+}
+
+func (s *recordingSpan) SetAttributes(attrs ...Attribute) {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+
+	for _, a := range attrs {
+		s.span.attrs[a.Key] = a.Value
+	}
+}
+
+func (s *recordingSpan) AddEvent(name string, attrs ...Attribute) {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+
+	eventAttrs := make(map[string]any)
+	for _, a := range attrs {
+		eventAttrs[a.Key] = a.Value
+	}
+
+	s.span.events = append(s.span.events, name)
+	s.span.eventAttrs = append(s.span.eventAttrs, eventAttrs)
+}
+
+func (s *recordingSpan) RecordError(err error) {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+
+	s.span.err = err
+}
+
+func (s *recordingSpan) End() {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+
+	s.span.ended = true
+}
+
+func TestClientTrace_concurrentDials(t *testing.T) {
+	t.Parallel()
+
+	tracer := &recordingTracer{}
+	_, span := tracer.Start(context.Background(), "HTTP GET")
+	trace := clientTrace(span)
+
+	// Happy Eyeballs: the IPv6 dial starts first and is slow, the IPv4 one starts later and finishes first
+	trace.ConnectStart("tcp", "[::1]:443")
+	time.Sleep(20 * time.Millisecond)
+	trace.ConnectStart("tcp", "127.0.0.1:443")
+
+	var wg sync.WaitGroup
+
+	for _, addr := range []string{"127.0.0.1:443", "[::1]:443"} {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			trace.ConnectDone("tcp", addr, nil)
+		}()
+	}
+
+	wg.Wait()
+
+	recorded := tracer.spans[0]
+	require.Len(t, recorded.eventAttrs, 2)
+
+	durations := make(map[string]time.Duration)
+	for _, attrs := range recorded.eventAttrs {
+		durations[attrs["net.peer.addr"].(string)] = attrs["duration"].(time.Duration)
+	}
+
+	assert.GreaterOrEqual(t, durations["[::1]:443"], 20*time.Millisecond)
+	assert.Less(t, durations["127.0.0.1:443"], durations["[::1]:443"], "each dial is timed from its own start")
+}
+
+func TestResourceFromURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		url      string
+		resource string
+		id       int
+	}{
+		{"https://www.comics.org/api/issue/42/", "issue", 42},
+		{"https://www.comics.org/api/series/name/Batman/year/2000/?page=2", "series", 0},
+		{"http://127.0.0.1:8080/api/indicia_publisher/2960/", "indicia_publisher", 2960},
+		{"https://www.comics.org/series/42/", "", 0},
+		{"://", "", 0},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.url, func(t *testing.T) {
+			t.Parallel()
+
+			resource, id := resourceFromURL(tt.url)
+			assert.Equal(t, tt.resource, resource)
+			assert.Equal(t, tt.id, id)
+		})
+	}
+}
+
+func TestAPI_Tracer(t *testing.T) {
+	t.Parallel()
+
+	var calls int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/issue/2495111/" {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintln(w, superman2023_1Issue)
+	}))
+	t.Cleanup(server.Close)
+
+	tracer := &recordingTracer{}
+
+	api := API{
+		Prefix: "http://" + server.Listener.Addr().String() + "/api/",
+		Retry:  &RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond},
+		Tracer: tracer,
+	}
+
+	_, err := api.Issue(context.Background(), IssueReq{ID: 2495111})
+	require.NoError(t, err)
+
+	require.Len(t, tracer.spans, 3)
+
+	call := tracer.spans[0]
+	assert.Equal(t, "gcd.issue", call.name)
+	assert.Equal(t, "issue", call.attrs["gcd.resource"])
+	assert.Equal(t, 2495111, call.attrs["gcd.id"])
+	assert.Equal(t, http.StatusOK, call.attrs["http.status_code"])
+	assert.Equal(t, 2, call.attrs["gcd.attempts"])
+	assert.NoError(t, call.err)
+	assert.True(t, call.ended)
+
+	first, second := tracer.spans[1], tracer.spans[2]
+	assert.Equal(t, "HTTP GET", first.name)
+	assert.Equal(t, 1, first.attrs["gcd.attempt"])
+	assert.Equal(t, http.StatusBadGateway, first.attrs["http.status_code"])
+	assert.Contains(t, first.events, "http.connect")
+	assert.Contains(t, first.events, "http.first_byte")
+	assert.True(t, first.ended)
+
+	assert.Equal(t, 2, second.attrs["gcd.attempt"])
+	assert.Equal(t, http.StatusOK, second.attrs["http.status_code"])
+
+	_, err = api.SeriesInstance(context.Background(), 196803)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	require.Len(t, tracer.spans, 5)
+	assert.Equal(t, "gcd.series", tracer.spans[3].name)
+	require.ErrorIs(t, tracer.spans[3].err, ErrNotFound, "call span records the error")
+	assert.True(t, tracer.spans[3].ended)
+}
@@ -0,0 +1,152 @@
+package gcd
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"golang.org/x/net/http2"
+)
+
+var ErrHTTP2Required = errors.New("gcd: server requires HTTP/2")
+
+// HTTP2RequiredError is returned when the server refuses a request made over HTTP/1.1, which happens when
+// HTTP/2 could not be negotiated, e.g. because a proxy terminates TLS and only speaks HTTP/1.1.
+type HTTP2RequiredError struct {
+	URL        string
+	StatusCode int
+	Proxied    bool // the request went through a proxy
+}
+
+func (e *HTTP2RequiredError) Error() string {
+	msg := fmt.Sprintf("gcd: %s refused HTTP/1.1 with status code %d, HTTP/2 is required", e.URL, e.StatusCode)
+	if e.Proxied {
+		msg += "; the proxy may not support HTTP/2 through CONNECT"
+	}
+
+	return msg
+}
+
+func (e *HTTP2RequiredError) Unwrap() error {
+	return ErrHTTP2Required
+}
+
+// TransportOptions tunes the transport built by NewTransport. Zero values use the defaults noted on each field.
+type TransportOptions struct {
+	Proxy     func(*http.Request) (*url.URL, error) // defaults to http.ProxyFromEnvironment
+	TLSConfig *tls.Config                           // defaults to TLS 1.2 or newer with the system roots
+
+	DialTimeout           time.Duration // defaults to 30s
+	KeepAlive             time.Duration // defaults to 30s
+	TLSHandshakeTimeout   time.Duration // defaults to 10s
+	ResponseHeaderTimeout time.Duration // defaults to 30s
+	IdleConnTimeout       time.Duration // defaults to 90s
+
+	MaxIdleConns        int // defaults to 100
+	MaxIdleConnsPerHost int // defaults to 10
+	MaxConnsPerHost     int // defaults to no limit
+
+	// HTTP2PingInterval is how long an HTTP/2 connection may stay silent before it is health checked with a ping.
+	// Defaults to 30s.
+	HTTP2PingInterval time.Duration
+}
+
+func orDefault[T comparable](value, fallback T) T {
+	var zero T
+	if value == zero {
+		return fallback
+	}
+
+	return value
+}
+
+// NewTransport returns a transport for the gcd api: HTTP/2 is negotiated whenever the server and proxy allow it,
+// falling back to HTTP/1.1 otherwise, and requests refused over HTTP/1.1 fail with *HTTP2RequiredError.
+func NewTransport(opts TransportOptions) (http.RoundTripper, error) {
+	for _, d := range []time.Duration{
+		opts.DialTimeout, opts.KeepAlive, opts.TLSHandshakeTimeout, opts.ResponseHeaderTimeout,
+		opts.IdleConnTimeout, opts.HTTP2PingInterval,
+	} {
+		if d < 0 {
+			return nil, errors.New("transport timeouts cannot be negative")
+		}
+	}
+
+	if opts.MaxIdleConns < 0 || opts.MaxIdleConnsPerHost < 0 || opts.MaxConnsPerHost < 0 {
+		return nil, errors.New("transport connection limits cannot be negative")
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if opts.TLSConfig != nil {
+		tlsConfig = opts.TLSConfig.Clone()
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   orDefault(opts.DialTimeout, 30*time.Second),
+		KeepAlive: orDefault(opts.KeepAlive, 30*time.Second),
+	}
+
+	proxy := opts.Proxy
+	if proxy == nil {
+		proxy = http.ProxyFromEnvironment
+	}
+
+	base := &http.Transport{
+		Proxy:                 proxy,
+		DialContext:           dialer.DialContext,
+		TLSClientConfig:       tlsConfig,
+		TLSHandshakeTimeout:   orDefault(opts.TLSHandshakeTimeout, 10*time.Second),
+		ResponseHeaderTimeout: orDefault(opts.ResponseHeaderTimeout, 30*time.Second),
+		IdleConnTimeout:       orDefault(opts.IdleConnTimeout, 90*time.Second),
+		MaxIdleConns:          orDefault(opts.MaxIdleConns, 100),
+		MaxIdleConnsPerHost:   orDefault(opts.MaxIdleConnsPerHost, 10),
+		MaxConnsPerHost:       opts.MaxConnsPerHost,
+		ForceAttemptHTTP2:     true,
+	}
+
+	// negotiates h2 through ALPN, also over CONNECT tunnels, which a bare http2.Transport cannot do
+	h2, err := http2.ConfigureTransports(base)
+	if err != nil {
+		return nil, fmt.Errorf("http2.ConfigureTransports: %w", err)
+	}
+
+	h2.ReadIdleTimeout = orDefault(opts.HTTP2PingInterval, 30*time.Second)
+	h2.PingTimeout = 15 * time.Second
+
+	return &transport{base: base}, nil
+}
+
+// transport reports servers refusing HTTP/1.1 with a clear error.
+type transport struct {
+	base *http.Transport
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.ProtoMajor >= 2 {
+		return resp, err
+	}
+
+	if resp.StatusCode != http.StatusUpgradeRequired && resp.StatusCode != http.StatusHTTPVersionNotSupported {
+		return resp, nil
+	}
+
+	drain(resp)
+
+	rejected := &HTTP2RequiredError{URL: req.URL.String(), StatusCode: resp.StatusCode}
+	if t.base.Proxy != nil {
+		if proxy, err := t.base.Proxy(req); err == nil && proxy != nil {
+			rejected.Proxied = true
+		}
+	}
+
+	return nil, rejected
+}
+
+func (t *transport) CloseIdleConnections() {
+	t.base.CloseIdleConnections()
+}
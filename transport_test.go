@@ -0,0 +1,126 @@
+package gcd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTransport_defaults(t *testing.T) {
+	t.Parallel()
+
+	rt, err := NewTransport(TransportOptions{MaxConnsPerHost: 4, IdleConnTimeout: time.Minute})
+	require.NoError(t, err)
+
+	base := rt.(*transport).base
+	assert.True(t, base.ForceAttemptHTTP2)
+	assert.NotNil(t, base.Proxy)
+	assert.Equal(t, 100, base.MaxIdleConns)
+	assert.Equal(t, 10, base.MaxIdleConnsPerHost)
+	assert.Equal(t, 4, base.MaxConnsPerHost)
+	assert.Equal(t, time.Minute, base.IdleConnTimeout)
+	assert.Equal(t, 10*time.Second, base.TLSHandshakeTimeout)
+	assert.Contains(t, base.TLSClientConfig.NextProtos, "h2")
+
+	_, err = NewTransport(TransportOptions{DialTimeout: -time.Second})
+	require.Error(t, err)
+
+	_, err = NewTransport(TransportOptions{MaxIdleConns: -1})
+	require.Error(t, err)
+}
+
+func TestNewTransport_http2(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintln(w, superman2023_1Issue)
+	}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	rt, err := NewTransport(TransportOptions{
+		TLSConfig: server.Client().Transport.(*http.Transport).TLSClientConfig,
+		Proxy:     func(*http.Request) (*url.URL, error) { return nil, nil },
+	})
+	require.NoError(t, err)
+
+	var proto string
+
+	api := API{
+		Prefix: server.URL + "/api/",
+		Client: &http.Client{Transport: rt},
+		Middlewares: []Middleware{TimingMiddleware(func(_ *http.Request, resp *http.Response, _ error, _ time.Duration) {
+			proto = resp.Proto
+		})},
+	}
+
+	_, err = api.Issue(context.Background(), IssueReq{ID: 2495111})
+	require.NoError(t, err)
+	assert.Equal(t, "HTTP/2.0", proto)
+}
+
+func TestNewTransport_http1Rejected(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ProtoMajor < 2 {
+			w.WriteHeader(http.StatusUpgradeRequired)
+
+			return
+		}
+
+		fmt.Fprintln(w, superman2023_1Issue)
+	}))
+	t.Cleanup(server.Close)
+
+	rt, err := NewTransport(TransportOptions{
+		TLSConfig: server.Client().Transport.(*http.Transport).TLSClientConfig,
+		Proxy:     func(*http.Request) (*url.URL, error) { return nil, nil },
+	})
+	require.NoError(t, err)
+
+	api := API{Prefix: server.URL + "/api/", Client: &http.Client{Transport: rt}}
+
+	_, err = api.Issue(context.Background(), IssueReq{ID: 2495111})
+	require.ErrorIs(t, err, ErrHTTP2Required)
+
+	var rejected *HTTP2RequiredError
+	require.ErrorAs(t, err, &rejected)
+	assert.Equal(t, http.StatusUpgradeRequired, rejected.StatusCode)
+	assert.False(t, rejected.Proxied)
+}
+
+func TestNewTransport_proxy(t *testing.T) {
+	t.Parallel()
+
+	var proxied string
+
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintln(w, superman2023_1Issue)
+	}))
+	t.Cleanup(proxy.Close)
+
+	proxyURL, err := url.Parse(proxy.URL)
+	require.NoError(t, err)
+
+	rt, err := NewTransport(TransportOptions{Proxy: http.ProxyURL(proxyURL)})
+	require.NoError(t, err)
+
+	api := API{Prefix: "http://gcd.invalid/api/", Client: &http.Client{Transport: rt}}
+
+	_, err = api.Issue(context.Background(), IssueReq{ID: 2495111})
+	require.NoError(t, err)
+	assert.Equal(t, "http://gcd.invalid/api/issue/2495111/", proxied)
+}
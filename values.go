@@ -0,0 +1,304 @@
+package gcd
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var ErrNoValue = errors.New("gcd: no value")
+
+// PageCountFloat parses PageCount, e.g. "36.000".
+func (r IssueResp) PageCountFloat() (float64, error) {
+	return parsePageCount(r.PageCount)
+}
+
+// PageCountFloat parses PageCount, e.g. "2.000".
+func (s StorySet) PageCountFloat() (float64, error) {
+	return parsePageCount(s.PageCount)
+}
+
+func parsePageCount(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, ErrNoValue
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("strconv.ParseFloat: %w", err)
+	}
+
+	return f, nil
+}
+
+// Money is a single price, e.g. "4.99 USD".
+type Money struct {
+	Amount   float64
+	Currency string // ISO 4217 code as used by gcd
+	Note     string // any trailing remark, e.g. "UK" in "0.50 GBP (UK)"
+}
+
+func (m Money) String() string {
+	s := strconv.FormatFloat(m.Amount, 'f', 2, 64) + " " + m.Currency
+	if m.Note != "" {
+		s += " (" + m.Note + ")"
+	}
+
+	return s
+}
+
+// Prices parses Price, which may list several currencies separated by ";", e.g. "4.99 USD; 6.50 CAD".
+// Entries that are not a price, such as "[none]" or "?", are skipped.
+func (r IssueResp) Prices() []Money {
+	var prices []Money
+
+	for _, entry := range splitTopLevel(r.Price, ';') {
+		name, groups := splitGroups(entry)
+
+		fields := strings.Fields(name)
+		if len(fields) < 2 {
+			continue
+		}
+
+		amount, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			continue
+		}
+
+		money := Money{Amount: amount, Currency: fields[1]}
+
+		var notes []string
+		for _, group := range groups {
+			notes = append(notes, group.text)
+		}
+
+		money.Note = strings.Join(notes, "; ")
+
+		prices = append(prices, money)
+	}
+
+	return prices
+}
+
+// PartialDate is a date where the month and day may be unknown, in which case they are zero.
+// gcd writes unknown parts as zeros, e.g. "2023-02-00".
+type PartialDate struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// ParsePartialDate parses "YYYY", "YYYY-MM" or "YYYY-MM-DD", where MM and DD may be "00".
+func ParsePartialDate(s string) (PartialDate, error) {
+	var date PartialDate
+
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return date, ErrNoValue
+	}
+
+	parts := strings.Split(s, "-")
+	if len(parts) > 3 {
+		return date, fmt.Errorf("invalid date %q", s)
+	}
+
+	values := make([]int, len(parts))
+
+	for i, part := range parts {
+		v, err := strconv.Atoi(part)
+		if err != nil || v < 0 {
+			return date, fmt.Errorf("invalid date %q", s)
+		}
+
+		values[i] = v
+	}
+
+	date.Year = values[0]
+	if len(values) > 1 {
+		date.Month = time.Month(values[1])
+	}
+
+	if len(values) > 2 {
+		date.Day = values[2]
+	}
+
+	if date.Year == 0 || date.Month > time.December || (date.Month == 0 && date.Day != 0) ||
+		(date.Day > 0 && date.Day > daysIn(date.Year, date.Month)) {
+		return PartialDate{}, fmt.Errorf("invalid date %q", s)
+	}
+
+	return date, nil
+}
+
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+func (d PartialDate) IsZero() bool {
+	return d == PartialDate{}
+}
+
+// IsComplete reports whether year, month and day are all known.
+func (d PartialDate) IsComplete() bool {
+	return d.Year != 0 && d.Month != 0 && d.Day != 0
+}
+
+// Time returns the first day the partial date may refer to.
+func (d PartialDate) Time() time.Time {
+	month, day := d.Month, d.Day
+	if month == 0 {
+		month = time.January
+	}
+
+	if day == 0 {
+		day = 1
+	}
+
+	return time.Date(d.Year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func (d PartialDate) String() string {
+	switch {
+	case d.Month == 0:
+		return fmt.Sprintf("%04d", d.Year)
+	case d.Day == 0:
+		return fmt.Sprintf("%04d-%02d", d.Year, int(d.Month))
+	}
+
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, int(d.Month), d.Day)
+}
+
+// OnSale parses OnSaleDate, e.g. "2023-02-21" or "2023-02-00".
+func (r IssueResp) OnSale() (PartialDate, error) {
+	return ParsePartialDate(r.OnSaleDate)
+}
+
+// Period is a range of months, as described by a publication date such as "April-May 2023".
+type Period struct {
+	From PartialDate
+	To   PartialDate
+}
+
+var periodMonths = map[string][2]time.Month{
+	"spring":  {time.March, time.May},
+	"summer":  {time.June, time.August},
+	"fall":    {time.September, time.November},
+	"autumn":  {time.September, time.November},
+	"winter":  {time.December, time.February},
+	"holiday": {time.December, time.December},
+}
+
+func lookupMonth(word string) ([2]time.Month, bool) {
+	if months, ok := periodMonths[word]; ok {
+		return months, true
+	}
+
+	for m := time.January; m <= time.December; m++ {
+		name := strings.ToLower(m.String())
+		if word == name || (len(word) >= 3 && strings.HasPrefix(name, word)) {
+			return [2]time.Month{m, m}, true
+		}
+	}
+
+	return [2]time.Month{}, false
+}
+
+// PublicationPeriod makes a best effort to interpret the free text PublicationDate, e.g. "April 2023",
+// "December 2022-January 2023" or "Winter 1990". Seasons span three months; winter ends in the following year.
+func (r IssueResp) PublicationPeriod() (Period, error) {
+	return ParsePeriod(r.PublicationDate)
+}
+
+// ParsePeriod is the parser behind IssueResp.PublicationPeriod.
+func ParsePeriod(s string) (Period, error) {
+	type token struct {
+		months [2]time.Month
+		year   int
+	}
+
+	var tokens []token
+
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	for _, word := range words {
+		if len(word) == 4 {
+			if year, err := strconv.Atoi(word); err == nil {
+				tokens = append(tokens, token{year: year})
+
+				continue
+			}
+		}
+
+		if months, ok := lookupMonth(word); ok {
+			tokens = append(tokens, token{months: months})
+		}
+	}
+
+	// every month belongs to the first year written after it, or the last year when none follows
+	var (
+		period    Period
+		lastYear  int
+		haveMonth bool
+	)
+
+	for i := len(tokens) - 1; i >= 0; i-- {
+		if tokens[i].year != 0 {
+			lastYear = tokens[i].year
+
+			continue
+		}
+
+		if lastYear == 0 {
+			for _, tok := range tokens[:i] {
+				if tok.year != 0 {
+					lastYear = tok.year
+				}
+			}
+		}
+
+		tokens[i].year = lastYear
+	}
+
+	for _, tok := range tokens {
+		if tok.year == 0 {
+			continue
+		}
+
+		if tok.months[0] == 0 {
+			if !haveMonth {
+				if period.From.IsZero() {
+					period.From = PartialDate{Year: tok.year}
+				}
+
+				period.To = PartialDate{Year: tok.year}
+			}
+
+			continue
+		}
+
+		from := PartialDate{Year: tok.year, Month: tok.months[0]}
+		to := PartialDate{Year: tok.year, Month: tok.months[1]}
+		if to.Month < from.Month {
+			to.Year++
+		}
+
+		if !haveMonth {
+			period.From = from
+			haveMonth = true
+		}
+
+		period.To = to
+	}
+
+	if period.From.IsZero() {
+		return Period{}, fmt.Errorf("no year in publication date %q", s)
+	}
+
+	return period, nil
+}
@@ -0,0 +1,157 @@
+package gcd
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIssueResp_values(t *testing.T) {
+	t.Parallel()
+
+	var issue IssueResp
+	require.NoError(t, json.Unmarshal([]byte(superman2023_1Issue), &issue))
+
+	pages, err := issue.PageCountFloat()
+	require.NoError(t, err)
+	assert.InDelta(t, 36.0, pages, 0.0001)
+
+	pages, err = issue.StorySet[1].PageCountFloat()
+	require.NoError(t, err)
+	assert.InDelta(t, 28.0, pages, 0.0001)
+
+	assert.Equal(t, []Money{{Amount: 4.99, Currency: "USD"}}, issue.Prices())
+
+	onSale, err := issue.OnSale()
+	require.NoError(t, err)
+	assert.Equal(t, PartialDate{Year: 2023, Month: time.February, Day: 21}, onSale)
+
+	period, err := issue.PublicationPeriod()
+	require.NoError(t, err)
+	assert.Equal(t, Period{
+		From: PartialDate{Year: 2023, Month: time.April},
+		To:   PartialDate{Year: 2023, Month: time.April},
+	}, period)
+
+	_, err = IssueResp{}.PageCountFloat()
+	require.ErrorIs(t, err, ErrNoValue)
+}
+
+func TestIssueResp_Prices(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		price    string
+		expected []Money
+	}{
+		{"empty", "", nil},
+		{"none", "[none]", nil},
+		{"unknown", "?", nil},
+		{
+			"multiple",
+			"2.95 USD; 4.25 CAD; 1.95 GBP (UK)",
+			[]Money{
+				{Amount: 2.95, Currency: "USD"},
+				{Amount: 4.25, Currency: "CAD"},
+				{Amount: 1.95, Currency: "GBP", Note: "UK"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, IssueResp{Price: tt.price}.Prices())
+		})
+	}
+}
+
+func TestParsePartialDate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input     string
+		expected  PartialDate
+		shouldErr bool
+	}{
+		{"2023-02-21", PartialDate{2023, time.February, 21}, false},
+		{"2023-02-00", PartialDate{2023, time.February, 0}, false},
+		{"2023-00-00", PartialDate{2023, 0, 0}, false},
+		{"2023-02", PartialDate{2023, time.February, 0}, false},
+		{"2023", PartialDate{2023, 0, 0}, false},
+		{"", PartialDate{}, true},
+		{"2023-13-01", PartialDate{}, true},
+		{"2023-02-30", PartialDate{}, true},
+		{"2023-00-12", PartialDate{}, true},
+		{"April 2023", PartialDate{}, true},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+
+			date, err := ParsePartialDate(tt.input)
+			if tt.shouldErr {
+				require.Error(t, err, "expected error")
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, date)
+		})
+	}
+
+	assert.Equal(t, "2023-02-21", PartialDate{2023, time.February, 21}.String())
+	assert.Equal(t, "2023-02", PartialDate{2023, time.February, 0}.String())
+	assert.Equal(t, "2023", PartialDate{2023, 0, 0}.String())
+	assert.Equal(t, time.Date(2023, time.February, 1, 0, 0, 0, 0, time.UTC), PartialDate{2023, time.February, 0}.Time())
+	assert.True(t, PartialDate{2023, time.February, 21}.IsComplete())
+	assert.False(t, PartialDate{2023, time.February, 0}.IsComplete())
+}
+
+func TestParsePeriod(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input     string
+		from, to  PartialDate
+		shouldErr bool
+	}{
+		{"April 2023", PartialDate{2023, time.April, 0}, PartialDate{2023, time.April, 0}, false},
+		{"April-May 2023", PartialDate{2023, time.April, 0}, PartialDate{2023, time.May, 0}, false},
+		{"December 2022-January 2023", PartialDate{2022, time.December, 0}, PartialDate{2023, time.January, 0}, false},
+		{"Sept. 1985", PartialDate{1985, time.September, 0}, PartialDate{1985, time.September, 0}, false},
+		{"Summer 1990", PartialDate{1990, time.June, 0}, PartialDate{1990, time.August, 0}, false},
+		{"Winter 1990", PartialDate{1990, time.December, 0}, PartialDate{1991, time.February, 0}, false},
+		{"1938", PartialDate{1938, 0, 0}, PartialDate{1938, 0, 0}, false},
+		{"1938-1939", PartialDate{1938, 0, 0}, PartialDate{1939, 0, 0}, false},
+		{"", PartialDate{}, PartialDate{}, true},
+		{"undated", PartialDate{}, PartialDate{}, true},
+	}
+
+	for _, test := range tests {
+		tt := test
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+
+			period, err := ParsePeriod(tt.input)
+			if tt.shouldErr {
+				require.Error(t, err, "expected error")
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.from, period.From, "From")
+			assert.Equal(t, tt.to, period.To, "To")
+		})
+	}
+}